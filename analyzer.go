@@ -0,0 +1,128 @@
+package taphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CharFilter transforms text before it is tokenized.
+type CharFilter func(string) string
+
+// TokenFilter transforms, drops or adds tokens after tokenization.
+type TokenFilter func([]Token) []Token
+
+// KeyEmitter returns the keys of a token, indexed by KeyLevel. Tokens
+// with an empty key0 are dropped.
+type KeyEmitter func(Token) [3]string
+
+// Term is a token with its keys, as produced by an Analyzer.
+type Term struct {
+	Token
+	Keys [3]string
+}
+
+// Analyzer is a text analysis pipeline that turns text into keyed terms:
+// char filters, a tokenizer, token filters and a key emitter, applied in
+// that order. Integrations that index or search text should share one
+// analyzer so that documents and queries are analysed alike.
+type Analyzer struct {
+	CharFilters  []CharFilter
+	Tokenizer    func(string) []Token
+	TokenFilters []TokenFilter
+	Emitter      KeyEmitter
+}
+
+// Analyzer returns the encoder's default analyzer, the pipeline used by
+// EncodePhrase: the encoder's filters (profiles, expansions and other
+// options), Tokenize, TamilTokens, the encoder's stopwords and the
+// encoder's keys of each token's Base. With WithNgramFallback, only
+// punctuation is dropped instead of all non-Tamil tokens.
+func (k *TAphone) Analyzer() *Analyzer {
+	var script TokenFilter = TamilTokens
+	if k.ngram > 0 {
+		script = dropPunct
+	}
+	return &Analyzer{
+		CharFilters:  append([]CharFilter(nil), k.filters...),
+		Tokenizer:    Tokenize,
+		TokenFilters: []TokenFilter{script, stopwordFilter(k.stopwords)},
+		Emitter: func(t Token) [3]string {
+			return k.encode(t.Base)
+		},
+	}
+}
+
+// Analyze runs text through the analyzer and returns its terms in order.
+func (a *Analyzer) Analyze(text string) []Term {
+	for _, f := range a.CharFilters {
+		text = f(text)
+	}
+
+	toks := a.Tokenizer(text)
+	for _, f := range a.TokenFilters {
+		toks = f(toks)
+	}
+
+	out := make([]Term, 0, len(toks))
+	for _, t := range toks {
+		keys := a.Emitter(t)
+		if keys[Key0] == "" {
+			continue
+		}
+		out = append(out, Term{Token: t, Keys: keys})
+	}
+	return out
+}
+
+// TamilTokens is a token filter that drops non-Tamil tokens.
+func TamilTokens(toks []Token) []Token {
+	out := toks[:0:0]
+	for _, t := range toks {
+		if t.Kind == TokenTamil {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// dropPunct is a token filter that drops punctuation tokens, keeping
+// symbols.
+func dropPunct(toks []Token) []Token {
+	out := toks[:0:0]
+	for _, t := range toks {
+		if t.Kind != TokenPunct || strings.IndexFunc(t.Text, unicode.IsPunct) < 0 {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// StemTokens is a token filter that replaces the Base of each Tamil token
+// with its Stem.
+func StemTokens(toks []Token) []Token {
+	out := make([]Token, len(toks))
+	for i, t := range toks {
+		if t.Kind == TokenTamil {
+			t.Base = Stem(t.Base)
+		}
+		out[i] = t
+	}
+	return out
+}
+
+// StopwordFilter returns a token filter that drops the given words.
+func StopwordFilter(words ...string) TokenFilter {
+	return stopwordFilter(makeSet(words))
+}
+
+func stopwordFilter(words map[string]bool) TokenFilter {
+	return func(toks []Token) []Token {
+		out := toks[:0:0]
+		for _, t := range toks {
+			if !words[t.Text] && !words[t.Base] {
+				out = append(out, t)
+			}
+		}
+		return out
+	}
+}
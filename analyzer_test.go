@@ -0,0 +1,92 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzer(t *testing.T) {
+	type term struct {
+		text, base string
+	}
+	var (
+		stemmed = New().Analyzer()
+		custom  = &Analyzer{
+			Tokenizer:    Tokenize,
+			TokenFilters: []TokenFilter{TamilTokens, StemTokens, StopwordFilter("மரம்")},
+			Emitter:      stemmed.Emitter,
+		}
+	)
+	stemmed.TokenFilters = append(stemmed.TokenFilters, StemTokens)
+
+	cases := []struct {
+		name  string
+		a     *Analyzer
+		input string
+		want  []term
+	}{
+		{"default", New().Analyzer(), "இந்த வீடு, 2 house மரமும்!", []term{
+			{"வீடு", "வீடு"},
+			{"மரமும்", "மரம்"},
+		}},
+		{"no stopwords", New(WithStopwords()).Analyzer(), "இந்த வீடு", []term{
+			{"இந்த", "இந்த"},
+			{"வீடு", "வீடு"},
+		}},
+		{"char filters", New(WithProfile(NameProfile)).Analyzer(), "திரு. முருகன்", []term{
+			{"முருகன்", "முருகன்"},
+		}},
+		{"stemming", stemmed, "வீடுகளில் மரங்கள்", []term{
+			{"வீடுகளில்", "வீடு"},
+			{"மரங்கள்", "மரம்"},
+		}},
+		{"custom stopwords after stemming", custom, "வீடுகள் மரங்கள்", []term{
+			{"வீடுகள்", "வீடு"},
+		}},
+		{"empty", New().Analyzer(), "", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := c.a.Analyze(c.input)
+			if len(got) != len(c.want) {
+				t.Fatalf("Analyze(%q) = %d terms %+v, want %d", c.input, len(got), got, len(c.want))
+			}
+			for i, w := range c.want {
+				if got[i].Text != w.text || got[i].Base != w.base {
+					t.Errorf("term %d = %q (%q), want %q (%q)", i, got[i].Text, got[i].Base, w.text, w.base)
+				}
+				if got[i].Keys != New().encode(w.base) {
+					t.Errorf("term %d keys = %v, want the keys of %q", i, got[i].Keys, w.base)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenFilters(t *testing.T) {
+	toks := Tokenize("அவனும் hello, ₹ 12")
+	cases := []struct {
+		name   string
+		filter TokenFilter
+		want   []string
+	}{
+		{"tamil tokens", TamilTokens, []string{"அவனும்"}},
+		{"drop punctuation", dropPunct, []string{"அவனும்", "hello", "₹", "12"}},
+		{"stopwords by base", StopwordFilter("அவன்"), []string{"hello", ",", "₹", "12"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := c.filter(toks)
+			var texts []string
+			for _, t := range got {
+				texts = append(texts, t.Text)
+			}
+			if !reflect.DeepEqual(texts, c.want) {
+				t.Errorf("filter = %q, want %q", texts, c.want)
+			}
+		})
+	}
+	if toks[0].Text != "அவனும்" || len(toks) != 5 {
+		t.Errorf("filters changed their input: %+v", toks)
+	}
+}
@@ -0,0 +1,85 @@
+package taphone
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// archaicSigns maps the two part vowel signs and vowels written as their
+// separate parts by older encodings and OCR (கெ + ா) to their composed
+// forms, and the rare ஶ to ஸ. ஶ is folded to ஸ rather than ஷ as
+// WithLoanSounds codes the two alike (S), so that keys don't depend on
+// the order of the options.
+var archaicSigns = strings.NewReplacer(
+	"\u0bc6\u0bbe", "\u0bca", // ெ + ா → ொ
+	"\u0bc7\u0bbe", "\u0bcb", // ே + ா → ோ
+	"\u0bc6\u0bd7", "\u0bcc", // ெ + ௗ → ௌ
+	"\u0b92\u0bd7", "\u0b94", // ஒ + ௗ → ஔ
+	"ஶ", "ஸ",
+)
+
+// archaicSymbols maps the Tamil calendrical, clerical and numeric
+// symbols, and ௐ, to the words they stand for.
+var archaicSymbols = map[rune]string{
+	'௳': "நாள்", '௴': "மாதம்", '௵': "வருடம்", '௶': "பற்று", '௷': "வரவு",
+	'௸': "மேற்படி", '௹': "ரூபாய்", '௺': "எண்", '௰': "பத்து", '௱': "நூறு",
+	'௲': "ஆயிரம்", 'ௐ': "ஓம்",
+}
+
+// regexArchaicAu matches ௌ and ஔ written with the letter ள in place of the
+// length mark (கெளரி, ஒளவை), where ள is followed by something other than a
+// sign of its own.
+var regexArchaicAu = regexp.MustCompile(`(ெ|ஒ)ள([^\x{0bbe}-\x{0bcd}\x{0bd7}]|$)`)
+
+// NormalizeArchaic normalizes the conventions of older printed texts and
+// legacy font encodings that survive in digitized archives to modern
+// Unicode Tamil: vowel signs written as separate parts (கொ → கொ), ௌ and ஔ
+// written with ள (கெளரி → கௌரி, ஒளவை → ஔவை), ஶ (→ ஸ), and the calendrical,
+// clerical and numeric symbols (௳, ௹, ௱), which are replaced by the words
+// they stand for. The pre-reform shapes of ணா, றா, னா, லை and the like
+// need no normalization as Unicode encodes them alike.
+func NormalizeArchaic(s string) string {
+	s = archaicSigns.Replace(s)
+	s = regexArchaicAu.ReplaceAllStringFunc(s, func(m string) string {
+		sm := regexArchaicAu.FindStringSubmatch(m)
+		if sm[1] == "ஒ" {
+			return "ஔ" + sm[2]
+		}
+		return "ௌ" + sm[2]
+	})
+
+	if !strings.ContainsAny(s, "௳௴௵௶௷௸௹௺௰௱௲ௐ") {
+		return s
+	}
+	var (
+		rs  = []rune(s)
+		out strings.Builder
+	)
+	for i, r := range rs {
+		w, ok := archaicSymbols[r]
+		if !ok {
+			out.WriteRune(r)
+			continue
+		}
+
+		// Symbols are written attached to numbers and words (௧௨௳), so
+		// their words are set apart.
+		if i > 0 && !unicode.IsSpace(rs[i-1]) && !strings.HasSuffix(out.String(), " ") {
+			out.WriteByte(' ')
+		}
+		out.WriteString(w)
+		if i+1 < len(rs) && !unicode.IsSpace(rs[i+1]) {
+			out.WriteByte(' ')
+		}
+	}
+	return out.String()
+}
+
+// WithArchaicNormalization normalizes older orthography in the input with
+// NormalizeArchaic before it is encoded.
+func WithArchaicNormalization() Option {
+	return func(k *TAphone) {
+		k.addFilter("archaic", NormalizeArchaic)
+	}
+}
@@ -0,0 +1,46 @@
+package taphone
+
+import "testing"
+
+func TestNormalizeArchaic(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"split o sign", "கொடு", "கொடு"},
+		{"split oo sign", "கோலம்", "கோலம்"},
+		{"split au sign", "கௌரி", "கௌரி"},
+		{"au sign with ள", "கெளரி", "கௌரி"},
+		{"au vowel with ள", "ஒளவை", "ஔவை"},
+		{"au at the end", "கெள", "கௌ"},
+		{"ள with a sign of its own", "கெளி", "கெளி"},
+		{"ள with a virama", "தெள்ளு", "தெள்ளு"},
+		{"sha", "ஶ்ரீ", "ஸ்ரீ"},
+		{"attached symbol", "௧௨௳", "௧௨ நாள்"},
+		{"symbol between words", "௹௧௦௦", "ரூபாய் ௧௦௦"},
+		{"spaced symbol", "விலை ௹ ௧௦", "விலை ரூபாய் ௧௦"},
+		{"om", "ௐ", "ஓம்"},
+		{"modern text", "வணக்கம்", "வணக்கம்"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := NormalizeArchaic(c.input); got != c.want {
+				t.Errorf("NormalizeArchaic(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithArchaicNormalization(t *testing.T) {
+	k := New(WithArchaicNormalization())
+	cases := []struct{ archaic, modern string }{
+		{"கொடு", "கொடு"},
+		{"கெளரி", "கௌரி"},
+	}
+	for _, c := range cases {
+		if got, want := k.EncodeLevel(c.archaic, Key2), New().EncodeLevel(c.modern, Key2); got != want {
+			t.Errorf("key2 of %q = %q, want %q", c.archaic, got, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Package arrow computes taphone keys of Apache Arrow string columns in
+// bulk, so that analytics pipelines that use Arrow or Parquet can key
+// whole columns without calling the encoder row by row. It is a separate
+// module so that the taphone module stays free of dependencies.
+package arrow
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/cmrajan/taphone"
+)
+
+// EncodeColumn encodes a column of Tamil words, of any Arrow string type,
+// with taphone.TAphone.EncodeColumn and returns the columns of their keys
+// as string arrays indexed by taphone.KeyLevel. The keys of a null word
+// are null. The caller must release the arrays.
+func EncodeColumn(enc *taphone.TAphone, col arrow.Array, mem memory.Allocator) ([3]arrow.Array, error) {
+	var out [3]arrow.Array
+	strs, ok := col.(array.StringLike)
+	if !ok {
+		return out, fmt.Errorf("taphone/arrow: column of type %s is not a string column", col.DataType())
+	}
+
+	var (
+		words = make([]string, strs.Len())
+		valid = make([]bool, strs.Len())
+	)
+	for i := range words {
+		if valid[i] = strs.IsValid(i); valid[i] {
+			words[i] = strs.Value(i)
+		}
+	}
+
+	cols := enc.EncodeColumn(words)
+	for l := range cols {
+		b := array.NewStringBuilder(mem)
+		b.AppendValues(cols[l], valid)
+		out[l] = b.NewStringArray()
+		b.Release()
+	}
+	return out, nil
+}
+
+// AddKeys returns rec with the keys of the named string column appended
+// as the columns <name>_key0, <name>_key1 and <name>_key2, like
+// taphone.TAphone.EncodeCSV. The caller must release the returned record.
+func AddKeys(enc *taphone.TAphone, rec arrow.RecordBatch, name string, mem memory.Allocator) (arrow.RecordBatch, error) {
+	idx := rec.Schema().FieldIndices(name)
+	if len(idx) == 0 {
+		return nil, fmt.Errorf("taphone/arrow: no column %q", name)
+	}
+
+	keys, err := EncodeColumn(enc, rec.Column(idx[0]), mem)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, k := range keys {
+			k.Release()
+		}
+	}()
+
+	var (
+		fields = append([]arrow.Field(nil), rec.Schema().Fields()...)
+		cols   = append([]arrow.Array(nil), rec.Columns()...)
+	)
+	for l, k := range keys {
+		fields = append(fields, arrow.Field{
+			Name:     fmt.Sprintf("%s_key%d", name, l),
+			Type:     arrow.BinaryTypes.String,
+			Nullable: true,
+		})
+		cols = append(cols, k)
+	}
+	meta := rec.Schema().Metadata()
+	return array.NewRecordBatch(arrow.NewSchema(fields, &meta), cols, rec.NumRows()), nil
+}
@@ -0,0 +1,80 @@
+package taphone
+
+import "strings"
+
+// brailleConsonants maps Bharati Braille cells to the Tamil consonants
+// they stand for, and brailleVowels those of the vowels. A vowel cell
+// that follows a consonant stands for its vowel sign.
+var (
+	brailleConsonants = map[rune]string{
+		'⠅': "க", '⠬': "ங", '⠉': "ச", '⠒': "ஞ", '⠾': "ட", '⠼': "ண", '⠞': "த",
+		'⠝': "ந", '⠏': "ப", '⠍': "ம", '⠽': "ய", '⠗': "ர", '⠇': "ல", '⠧': "வ",
+		'⠷': "ழ", '⠸': "ள", '⠻': "ற", '⠰': "ன",
+
+		// Grantha.
+		'⠚': "ஜ", '⠩': "ஶ", '⠯': "ஷ", '⠎': "ஸ", '⠓': "ஹ", '⠟': "க்ஷ",
+	}
+	brailleVowels = map[rune]rune{
+		'⠁': 'அ', '⠜': 'ஆ', '⠊': 'இ', '⠔': 'ஈ', '⠥': 'உ', '⠳': 'ஊ', '⠢': 'எ',
+		'⠑': 'ஏ', '⠌': 'ஐ', '⠭': 'ஒ', '⠕': 'ஓ', '⠪': 'ஔ',
+	}
+)
+
+const (
+	braillePulli  = '⠈'
+	brailleAytham = '⠠'
+	brailleBlank  = '⠀'
+)
+
+// FromBraille converts Tamil text in Bharati Braille, written with the
+// Unicode Braille patterns, to Unicode Tamil (⠅⠁⠍⠇⠈ → கமல்). A consonant
+// takes the vowel sign of the vowel cell that follows it, or the pulli
+// (dot 4) that follows it, and is otherwise read with its inherent அ.
+// The blank cell is read as a space and other characters are kept as
+// they are. As ⠼ is ண in Tamil, the number sign isn't supported.
+func FromBraille(s string) string {
+	var (
+		out  strings.Builder
+		cons = false
+	)
+	for _, r := range s {
+		if c, ok := brailleConsonants[r]; ok {
+			out.WriteString(c)
+			cons = true
+			continue
+		}
+
+		after := cons
+		cons = false
+		if v, ok := brailleVowels[r]; ok {
+			if after {
+				out.WriteString(vowelSigns[v])
+			} else {
+				out.WriteRune(v)
+			}
+			continue
+		}
+
+		switch r {
+		case braillePulli:
+			if after {
+				out.WriteString("்")
+			}
+		case brailleAytham:
+			out.WriteString("ஃ")
+		case brailleBlank:
+			out.WriteByte(' ')
+		default:
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+// WithBraille converts Bharati Braille in the input to Tamil with
+// FromBraille before it is encoded.
+func WithBraille() Option {
+	return func(k *TAphone) {
+		k.addFilter("braille", FromBraille)
+	}
+}
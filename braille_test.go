@@ -0,0 +1,35 @@
+package taphone
+
+import "testing"
+
+func TestFromBraille(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"inherent and explicit a", "⠅⠁⠍⠇⠈", "கமல்"},
+		{"vowel signs", "⠧⠜⠼⠊", "வாணி"},
+		{"initial vowel", "⠁⠍⠈⠍⠜", "அம்மா"},
+		{"vowel after a vowel", "⠁⠊", "அஇ"},
+		{"pulli without a consonant", "⠈⠁", "அ"},
+		{"aytham", "⠠⠏⠑⠰⠈", "ஃபேன்"},
+		{"grantha", "⠚⠢⠽⠜", "ஜெயா"},
+		{"blank cell", "⠍⠗⠍⠈⠀⠅⠇⠈", "மரம் கல்"},
+		{"other characters", "⠍⠗⠍⠈, 12", "மரம், 12"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := FromBraille(c.input); got != c.want {
+				t.Errorf("FromBraille(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithBraille(t *testing.T) {
+	k := New(WithBraille())
+	if got, want := k.EncodeLevel("⠅⠁⠍⠇⠈", Key2), New().EncodeLevel("கமல்", Key2); got != want {
+		t.Errorf("key2 = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package taphone
+
+// EncodeOption configures a single Encode call. Any Option can be used.
+type EncodeOption = Option
+
+// WithMaxLength truncates keys to at most n codes, each with its
+// modifiers (n = 2: VNKKM → VN). n < 1 disables truncation.
+func WithMaxLength(n int) Option {
+	return func(k *TAphone) {
+		k.maxLen = n
+	}
+}
+
+// with returns a copy of the encoder with opts applied. The copy shares
+// no mutable state with k.
+func (k *TAphone) with(opts []EncodeOption) *TAphone {
+	c := *k
+
+	// Cap the slices so that appending to them reallocates, and copy the
+	// maps that options modify in place.
+	c.filters = c.filters[:len(c.filters):len(c.filters)]
+	c.filterNames = c.filterNames[:len(c.filterNames):len(c.filterNames)]
+	c.profiles = c.profiles[:len(c.profiles):len(c.profiles)]
+	if c.expansions != nil {
+		c.expansions = copyMap(c.expansions)
+	}
+
+	for _, o := range opts {
+		o(&c)
+	}
+	return &c
+}
+
+// truncateKey truncates a key to at most n codes.
+func truncateKey(key string, n int) string {
+	toks, ok := tokenizeKey(key)
+	if !ok || len(toks) <= n {
+		return key
+	}
+
+	l := 0
+	for _, t := range toks[:n] {
+		l += len(t.code) + len(t.mods)
+	}
+	return key[:l]
+}
@@ -0,0 +1,81 @@
+package taphone
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestEncodeOptions(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  []EncodeOption
+		input string
+		keys  [3]string
+	}{
+		{"none", nil, "முருகன்", [3]string{"MRKN", "MRKN1", "M4R4KN1"}},
+		{"max length", []EncodeOption{WithMaxLength(2)}, "முருகன்", [3]string{"MR", "MR", "M4R4"}},
+		{"max length longer than the key", []EncodeOption{WithMaxLength(10)}, "முருகன்", [3]string{"MRKN", "MRKN1", "M4R4KN1"}},
+		{"max length disabled", []EncodeOption{WithMaxLength(0)}, "முருகன்", [3]string{"MRKN", "MRKN1", "M4R4KN1"}},
+		{"lowercase", []EncodeOption{WithLowercase()}, "முருகன்", [3]string{"mrkn", "mrkn1", "m4r4kn1"}},
+		{"filters", []EncodeOption{WithProfile(NameProfile)}, "திரு. முருகன்", [3]string{"MRKN", "MRKN1", "M4R4KN1"}},
+	}
+	k := New()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got [3]string
+			got[0], got[1], got[2] = k.Encode(c.input, c.opts...)
+			if got != c.keys {
+				t.Errorf("Encode(%q) = %q, want %q", c.input, got, c.keys)
+			}
+		})
+	}
+
+	// Per-call options don't change the encoder.
+	if got := k.EncodeLevel("திரு. முருகன்", Key0); got != "T3RMRKN" {
+		t.Errorf("EncodeLevel() = %q after per-call options, want %q", got, "T3RMRKN")
+	}
+}
+
+func TestEncodeOptionsShareNoState(t *testing.T) {
+	k := New(WithProfile(NameProfile), WithExpansions(map[string]string{"டா.": "டாக்டர்"}))
+	n := len(k.filters)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			opt := WithExpansions(map[string]string{"பேரா.": "பேராசிரியர்"})
+			if i%2 == 0 {
+				opt = WithMarkupStripping()
+			}
+			k.Encode("டா. <b>முருகன்</b>", opt)
+		}(i)
+	}
+	wg.Wait()
+
+	if len(k.filters) != n || len(k.filterNames) != n || len(k.Rules().Expansions) != 1 {
+		t.Errorf("encoder has %d filters, %d filter names and %d expansions after per-call options, want %d, %d and 1",
+			len(k.filters), len(k.filterNames), len(k.Rules().Expansions), n, n)
+	}
+}
+
+func TestTruncateKey(t *testing.T) {
+	cases := []struct {
+		key  string
+		n    int
+		want string
+	}{
+		{"VNKKM", 2, "VN"},
+		{"M4R4KN1", 3, "M4R4K"},
+		{"T1M3Z", 1, "T1"},
+		{"NGK", 1, "NG"},
+		{"VN", 2, "VN"},
+		{"", 2, ""},
+	}
+	for _, c := range cases {
+		if got := truncateKey(c.key, c.n); got != c.want {
+			t.Errorf("truncateKey(%q, %d) = %q, want %q", c.key, c.n, got, c.want)
+		}
+	}
+}
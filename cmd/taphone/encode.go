@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cmrajan/taphone"
+)
+
+func runEncode(args []string) error {
+	fs := flag.NewFlagSet("encode", flag.ExitOnError)
+	var (
+		level = fs.Int("level", -1, "print only the key at this level (0, 1 or 2)")
+		cols  = fs.String("csv", "", "read CSV and append the keys of these comma separated columns")
+		paths = fs.String("json", "", "read NDJSON and add the keys of these comma separated paths")
+		dir   = fs.String("watch", "", "watch the files in this directory and write the keys of changed lines as NDJSON")
+		every = fs.Duration("interval", time.Second, "interval between polls of -watch")
+	)
+	fs.Parse(args)
+
+	switch {
+	case *dir != "":
+		return watch(taphone.New(), *dir, *every, os.Stdout)
+	case *cols != "":
+		return encodeFile(fs.Args(), func(r io.Reader, w io.Writer) error {
+			return taphone.New().EncodeCSV(r, w, strings.Split(*cols, ",")...)
+		})
+	case *paths != "":
+		return encodeFile(fs.Args(), func(r io.Reader, w io.Writer) error {
+			return taphone.New().EncodeNDJSON(r, w, strings.Split(*paths, ",")...)
+		})
+	}
+
+	if *level > int(taphone.Key2) {
+		return fmt.Errorf("invalid level %d", *level)
+	}
+
+	var (
+		k   = taphone.New()
+		out = bufio.NewWriter(os.Stdout)
+	)
+	defer out.Flush()
+
+	encode := func(w string) {
+		k0, k1, k2 := k.Encode(w)
+		switch *level {
+		case 0:
+			fmt.Fprintf(out, "%s\t%s\n", w, k0)
+		case 1:
+			fmt.Fprintf(out, "%s\t%s\n", w, k1)
+		case 2:
+			fmt.Fprintf(out, "%s\t%s\n", w, k2)
+		default:
+			fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", w, k0, k1, k2)
+		}
+	}
+
+	if fs.NArg() > 0 {
+		for _, w := range fs.Args() {
+			encode(w)
+		}
+		return nil
+	}
+	return eachLine(os.Stdin, func(l string) {
+		if l = strings.TrimSpace(l); l != "" {
+			encode(l)
+		}
+	})
+}
+
+// encodeFile runs fn on the named file, or standard input, writing to
+// standard output.
+func encodeFile(files []string, fn func(io.Reader, io.Writer) error) error {
+	var in io.Reader = os.Stdin
+	if len(files) > 0 {
+		f, err := os.Open(files[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	if err := fn(in, out); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// eachLine calls fn with each line of r.
+func eachLine(r io.Reader, fn func(string)) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		fn(sc.Text())
+	}
+	return sc.Err()
+}
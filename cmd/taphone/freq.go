@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cmrajan/taphone/freq"
+)
+
+func runFreq(args []string) error {
+	if len(args) < 1 {
+		return errors.New("usage: taphone freq build|top [arguments]")
+	}
+
+	switch args[0] {
+	case "build":
+		return freqBuild(args[1:])
+	case "top":
+		return freqTop(args[1:])
+	}
+	return fmt.Errorf("unknown freq command %q", args[0])
+}
+
+// freqBuild builds a model from corpus files, or standard input.
+func freqBuild(args []string) error {
+	fs := flag.NewFlagSet("freq build", flag.ExitOnError)
+	var (
+		out = fs.String("o", "", "model file to write")
+		min = fs.Int("min", 1, "drop words that occur fewer than min times")
+	)
+	fs.Parse(args)
+
+	if *out == "" {
+		return errors.New("no output file (-o)")
+	}
+
+	var readers []io.Reader
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		readers = append(readers, f)
+	}
+	if len(readers) == 0 {
+		readers = append(readers, os.Stdin)
+	}
+
+	m, err := freq.Build(readers...)
+	if err != nil {
+		return err
+	}
+	m.Prune(*min)
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if _, err := m.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "%d words, %d occurrences\n", m.Len(), m.Total())
+	return nil
+}
+
+// freqTop prints the most frequent words of a model as a wordlist that
+// taphone.Dictionary can load.
+func freqTop(args []string) error {
+	fs := flag.NewFlagSet("freq top", flag.ExitOnError)
+	n := fs.Int("n", 0, "number of words to print; 0 prints all")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: taphone freq top [-n count] model")
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	m, err := freq.Read(f)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	for _, e := range m.Top(*n) {
+		fmt.Fprintf(out, "%s\t%d\n", e.Word, e.Count)
+	}
+	return out.Flush()
+}
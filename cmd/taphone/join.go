@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cmrajan/taphone"
+)
+
+// table is a CSV file read into memory.
+type table struct {
+	header []string
+	rows   [][]string
+	col    int
+}
+
+// runJoin joins two CSV files on the phonetic keys of a name column and
+// writes the matched pairs of rows with their similarity scores.
+func runJoin(args []string) error {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	var (
+		on    = fs.String("on", "", "name column to join on, or a,b for differently named columns")
+		level = fs.String("level", "key1", "key level to match on (key0, key1 or key2)")
+		min   = fs.Float64("min", 0.8, "drop pairs whose name similarity is below min")
+	)
+	files := parseInterspersed(fs, args)
+
+	if len(files) != 2 {
+		return errors.New("usage: taphone join -on column [-level key] [-min score] a.csv b.csv")
+	}
+	if *on == "" {
+		return errors.New("no column to join on (-on)")
+	}
+	l, err := parseLevel(*level)
+	if err != nil {
+		return err
+	}
+
+	colA, colB := *on, *on
+	if i := strings.Index(*on, ","); i > -1 {
+		colA, colB = (*on)[:i], (*on)[i+1:]
+	}
+	a, err := readTable(files[0], colA)
+	if err != nil {
+		return err
+	}
+	b, err := readTable(files[1], colB)
+	if err != nil {
+		return err
+	}
+
+	// Index the rows of b by the keys of the words of their names, so that
+	// names match regardless of word order.
+	var (
+		k     = taphone.New()
+		index = make(map[string][]int)
+	)
+	for i, row := range b.rows {
+		for _, key := range wordKeys(k, row[b.col], l) {
+			index[key] = append(index[key], i)
+		}
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	hdr := make([]string, 0, len(a.header)+len(b.header)+1)
+	for _, h := range a.header {
+		hdr = append(hdr, "a."+h)
+	}
+	for _, h := range b.header {
+		hdr = append(hdr, "b."+h)
+	}
+	if err := w.Write(append(hdr, "score")); err != nil {
+		return err
+	}
+
+	type match struct {
+		row   int
+		score float64
+	}
+	for _, ra := range a.rows {
+		var (
+			seen    = make(map[int]bool)
+			matches []match
+		)
+		for _, key := range wordKeys(k, ra[a.col], l) {
+			for _, i := range index[key] {
+				if seen[i] {
+					continue
+				}
+				seen[i] = true
+				if s := k.NameSimilarity(ra[a.col], b.rows[i][b.col]); s >= *min {
+					matches = append(matches, match{i, s})
+				}
+			}
+		}
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+		for _, m := range matches {
+			out := append(append(append([]string(nil), ra...), b.rows[m.row]...),
+				strconv.FormatFloat(m.score, 'f', 3, 64))
+			if err := w.Write(out); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// readTable reads a CSV file with a header, padding short rows to the
+// header's length, and finds the named column in it.
+func readTable(name, col string) (*table, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: no header", name)
+	}
+
+	t := &table{header: rows[0], rows: rows[1:], col: -1}
+	for i, h := range t.header {
+		if strings.EqualFold(strings.TrimSpace(h), col) {
+			t.col = i
+			break
+		}
+	}
+	if t.col < 0 {
+		return nil, fmt.Errorf("%s: header has no %s column", name, col)
+	}
+	for i, row := range t.rows {
+		for len(row) < len(t.header) {
+			row = append(row, "")
+		}
+		t.rows[i] = row
+	}
+	return t, nil
+}
+
+// wordKeys returns the keys at level l of the words of a name.
+func wordKeys(k *taphone.TAphone, name string, l taphone.KeyLevel) []string {
+	var out []string
+	for _, w := range strings.Fields(name) {
+		if key := k.EncodeLevel(w, l); key != "" {
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
+// parseLevel parses a key level given as key0, key1, key2 or 0, 1, 2.
+func parseLevel(s string) (taphone.KeyLevel, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(s), "key"))
+	if err != nil || n < int(taphone.Key0) || n > int(taphone.Key2) {
+		return 0, fmt.Errorf("invalid level %q", s)
+	}
+	return taphone.KeyLevel(n), nil
+}
+
+// parseInterspersed parses flags that may be given before, between or
+// after the positional arguments, and returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var pos []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return pos
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+}
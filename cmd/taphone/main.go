@@ -0,0 +1,65 @@
+// Command taphone encodes Tamil words to their phonetic keys, joins CSV
+// files on them, serves them over HTTP and builds word frequency models
+// from corpora.
+//
+// Usage:
+//
+//	taphone encode [-level n] [word ...]
+//	taphone encode -csv column,... [file]
+//	taphone encode -json path,... [file]
+//	taphone encode -watch dir [-interval duration]
+//	taphone join -on column [-level key] [-min score] a.csv b.csv
+//	taphone freq build [-min n] -o model [corpus ...]
+//	taphone freq top [-n count] model
+//	taphone serve [-addr host:port] [-dict wordlist]
+//	taphone seeds [-n count] [-fuzz dir] [-golden file] [corpus]
+//
+// Commands read standard input when no words or files are given.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command is a subcommand of taphone.
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"encode", "encode words to their phonetic keys", runEncode},
+	{"join", "join two CSV files on the phonetic keys of a name column", runJoin},
+	{"freq", "build and inspect word frequency models", runFreq},
+	{"serve", "serve keys and dictionary matches over HTTP, with a demo page", runServe},
+	{"seeds", "extract fuzz seeds and golden keys from a corpus", runSeeds},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	name := os.Args[1]
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		if err := c.run(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "taphone %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+	usage()
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: taphone <command> [arguments]\n\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c.name, c.usage)
+	}
+	os.Exit(2)
+}
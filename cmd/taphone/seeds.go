@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cmrajan/taphone"
+)
+
+// seedCategories are the kinds of edge cases collected by seeds, in the
+// order they are checked. A token falls into the first category that it
+// matches.
+var seedCategories = []struct {
+	name  string
+	match func(string) bool
+}{
+	{"mixed", func(s string) bool { return hasTamil(s) && strings.IndexFunc(s, isLatin) > -1 }},
+	{"numeral", func(s string) bool { return hasTamil(s) && strings.IndexFunc(s, unicode.IsDigit) > -1 }},
+	{"joiner", func(s string) bool { return strings.ContainsAny(s, "\u200c\u200d") }},
+	{"grantha", func(s string) bool { return strings.ContainsAny(s, "ஜஷஸஹஶ") }},
+	{"rare", func(s string) bool { return strings.ContainsAny(s, "ஃௌஂௐௗ") }},
+	{"long", func(s string) bool { return len(taphone.Graphemes(s)) >= 12 }},
+	{"cluster", hasCluster},
+	{"plain", hasTamil},
+}
+
+// runSeeds extracts diverse Tamil tokens from a corpus as fuzz seeds and
+// golden test cases.
+func runSeeds(args []string) error {
+	fs := flag.NewFlagSet("seeds", flag.ExitOnError)
+	var (
+		n      = fs.Int("n", 20, "maximum number of tokens per category")
+		fuzz   = fs.String("fuzz", "", "directory to write fuzz seed corpus files to, e.g. testdata/fuzz/FuzzEncode")
+		golden = fs.String("golden", "", "file to write golden keys (input, key0, key1, key2) to")
+	)
+	fs.Parse(args)
+
+	if *fuzz == "" && *golden == "" {
+		return errors.New("no output (-fuzz or -golden)")
+	}
+
+	var in io.Reader = os.Stdin
+	if fs.NArg() > 0 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	seeds, err := collectSeeds(in, taphone.New(), *n)
+	if err != nil {
+		return err
+	}
+
+	if *fuzz != "" {
+		if err := writeFuzzSeeds(*fuzz, seeds); err != nil {
+			return err
+		}
+	}
+	if *golden != "" {
+		if err := writeGolden(*golden, taphone.New(), seeds); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d seeds\n", len(seeds))
+	return nil
+}
+
+// collectSeeds returns up to n tokens of each category from r. Within a
+// category, tokens whose key2 was already seen are skipped so that the
+// seeds exercise different code paths.
+func collectSeeds(r io.Reader, k *taphone.TAphone, n int) ([]string, error) {
+	var (
+		seen  = make(map[string]bool)
+		keys  = make(map[string]bool)
+		count = make(map[string]int)
+		out   []string
+	)
+	err := eachLine(r, func(l string) {
+		for _, tok := range strings.Fields(l) {
+			tok = strings.TrimFunc(tok, unicode.IsPunct)
+			if seen[tok] {
+				continue
+			}
+			seen[tok] = true
+
+			for _, c := range seedCategories {
+				if !c.match(tok) {
+					continue
+				}
+				key := c.name + " " + k.EncodeLevel(tok, taphone.Key2)
+				if count[c.name] < n && !keys[key] {
+					keys[key] = true
+					count[c.name]++
+					out = append(out, tok)
+				}
+				break
+			}
+		}
+	})
+	sort.Strings(out)
+	return out, err
+}
+
+// writeFuzzSeeds writes each seed to its own file in the Go fuzzing
+// corpus format, named by its hash like the files the go command writes.
+func writeFuzzSeeds(dir string, seeds []string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, s := range seeds {
+		data := fmt.Sprintf("go test fuzz v1\nstring(%q)\n", s)
+		name := fmt.Sprintf("%x", sha256.Sum256([]byte(data)))[:16]
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeGolden writes the keys of each seed as tab separated values.
+func writeGolden(path string, k *taphone.TAphone, seeds []string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	for _, s := range seeds {
+		k0, k1, k2 := k.Encode(s)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s, k0, k1, k2)
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func hasTamil(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return unicode.Is(unicode.Tamil, r) && unicode.IsLetter(r)
+	}) > -1
+}
+
+func isLatin(r rune) bool {
+	return unicode.Is(unicode.Latin, r)
+}
+
+// hasCluster reports whether s has a consonant cluster: a virama
+// followed by a consonant.
+func hasCluster(s string) bool {
+	rs := []rune(s)
+	for i := 1; i < len(rs); i++ {
+		if rs[i-1] == '்' && unicode.IsLetter(rs[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/cmrajan/taphone"
+)
+
+//go:embed demo.html
+var demoPage []byte
+
+// serveMatches is the maximum number of dictionary matches returned.
+const serveMatches = 10
+
+// encodeResponse is the response of the /encode endpoint.
+type encodeResponse struct {
+	Input     string               `json:"input"`
+	Keys      [3]string            `json:"keys"`
+	Graphemes []string             `json:"graphemes"`
+	Phonemes  string               `json:"phonemes"`
+	Matches   []taphone.Suggestion `json:"matches"`
+}
+
+// runServe serves the phonetic keys and dictionary matches of words over
+// HTTP, with a demo page at /demo.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	var (
+		addr = fs.String("addr", "localhost:8080", "address to listen on")
+		dict = fs.String("dict", "", "wordlist to match against instead of the embedded one")
+	)
+	fs.Parse(args)
+
+	d := taphone.DefaultDictionary()
+	if *dict != "" {
+		f, err := os.Open(*dict)
+		if err != nil {
+			return err
+		}
+		d = taphone.NewDictionary(taphone.New())
+		err = d.Load(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %v", *dict, err)
+		}
+	}
+
+	var (
+		k   = taphone.New()
+		mux = http.NewServeMux()
+	)
+	mux.HandleFunc("/encode", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		res := encodeResponse{
+			Input:     q,
+			Graphemes: taphone.Graphemes(q),
+			Phonemes:  taphone.Phonemes(q, taphone.IPA),
+			Matches:   d.Suggest(q, serveMatches),
+		}
+		res.Keys[0], res.Keys[1], res.Keys[2] = k.Encode(q)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(res)
+	})
+	mux.HandleFunc("/demo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(demoPage)
+	})
+
+	fmt.Fprintf(os.Stderr, "serving %d words on http://%s/demo\n", d.Len(), *addr)
+	return http.ListenAndServe(*addr, mux)
+}
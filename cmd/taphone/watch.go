@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cmrajan/taphone"
+)
+
+// watchEvent is an NDJSON record written by watch: the keys of a line
+// that was added or changed, or a line or file that was deleted.
+type watchEvent struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Key0    string `json:"key0,omitempty"`
+	Key1    string `json:"key1,omitempty"`
+	Key2    string `json:"key2,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// watchedFile is the state of a file as of the last poll.
+type watchedFile struct {
+	mod   time.Time
+	size  int64
+	lines []string
+}
+
+// watch polls the files under dir every interval and writes the keys of
+// the lines that were added or changed since the last poll to w as
+// NDJSON, starting with every line. Lines are compared by line number,
+// so that inserting a line re-encodes the lines after it. Blank lines
+// are skipped, and lines that become blank are reported deleted. It
+// runs until it fails to read dir or write to w.
+func watch(k *taphone.TAphone, dir string, interval time.Duration, w io.Writer) error {
+	var (
+		files = make(map[string]*watchedFile)
+		bw    = bufio.NewWriter(w)
+		enc   = json.NewEncoder(bw)
+	)
+	enc.SetEscapeHTML(false)
+
+	for {
+		seen := make(map[string]bool)
+		err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+			if err != nil {
+				// Files may be removed while walking.
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if fi.IsDir() {
+				if path != dir && strings.HasPrefix(fi.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !fi.Mode().IsRegular() || strings.HasPrefix(fi.Name(), ".") {
+				return nil
+			}
+
+			seen[path] = true
+			f := files[path]
+			if f != nil && f.mod.Equal(fi.ModTime()) && f.size == fi.Size() {
+				return nil
+			}
+			lines, err := readLines(path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				// Skip files with over-long lines, such as minified or
+				// binary files, until they change, without stopping the
+				// watch.
+				if !errors.Is(err, bufio.ErrTooLong) {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "%s: skipped: %v\n", path, err)
+			}
+			if f == nil {
+				f = &watchedFile{}
+				files[path] = f
+			}
+			f.mod, f.size = fi.ModTime(), fi.Size()
+			if err != nil {
+				return nil
+			}
+			return emitChanges(k, enc, path, f, lines)
+		})
+		if err != nil {
+			return err
+		}
+
+		for path := range files {
+			if !seen[path] {
+				delete(files, path)
+				if err := enc.Encode(watchEvent{File: path, Deleted: true}); err != nil {
+					return err
+				}
+			}
+		}
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		time.Sleep(interval)
+	}
+}
+
+// emitChanges writes the events for the lines of f that differ from
+// lines, and updates f.
+func emitChanges(k *taphone.TAphone, enc *json.Encoder, path string, f *watchedFile, lines []string) error {
+	n := len(lines)
+	if len(f.lines) > n {
+		n = len(f.lines)
+	}
+	for i := 0; i < n; i++ {
+		var old, cur string
+		if i < len(f.lines) {
+			old = f.lines[i]
+		}
+		if i < len(lines) {
+			cur = lines[i]
+		}
+		if cur == old {
+			continue
+		}
+
+		ev := watchEvent{File: path, Line: i + 1}
+		if cur == "" {
+			ev.Deleted = true
+		} else {
+			ev.Text = cur
+			ev.Key0, ev.Key1, ev.Key2 = k.Encode(cur)
+		}
+		if err := enc.Encode(ev); err != nil {
+			return err
+		}
+	}
+	f.lines = lines
+	return nil
+}
+
+// readLines reads the lines of a file, trimmed of surrounding space.
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []string
+	err = eachLine(f, func(l string) {
+		out = append(out, strings.TrimSpace(l))
+	})
+	return out, err
+}
@@ -0,0 +1,52 @@
+package taphone
+
+import (
+	"runtime"
+	"sync"
+)
+
+// columnChunk is the number of words each worker of EncodeColumn encodes
+// at a time.
+const columnChunk = 1024
+
+// EncodeColumn encodes a column of words concurrently and returns the
+// columns of their keys indexed by KeyLevel, each of the same length as
+// words. It is meant for filling columnar formats (Arrow, Parquet) in
+// bulk: the keys of words[i] are at index i of each column. The
+// github.com/cmrajan/taphone/arrow module wraps it for Arrow arrays.
+func (k *TAphone) EncodeColumn(words []string) [3][]string {
+	var cols [3][]string
+	for l := range cols {
+		cols[l] = make([]string, len(words))
+	}
+
+	var (
+		chunks = make(chan int)
+		wg     sync.WaitGroup
+		p, _   = k.newProgress(nil)
+	)
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range chunks {
+				end := start + columnChunk
+				if end > len(words) {
+					end = len(words)
+				}
+				for i := start; i < end; i++ {
+					keys := k.keys(words[i])
+					cols[Key0][i], cols[Key1][i], cols[Key2][i] = keys[Key0], keys[Key1], keys[Key2]
+				}
+				p.add(end - start)
+			}
+		}()
+	}
+	for start := 0; start < len(words); start += columnChunk {
+		chunks <- start
+	}
+	close(chunks)
+	wg.Wait()
+	p.finish()
+	return cols
+}
@@ -0,0 +1,51 @@
+package taphone
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEncodeColumn(t *testing.T) {
+	var many []string
+	for i := 0; i < 3*columnChunk+7; i++ {
+		many = append(many, pipeInputs[i%len(pipeInputs)]+fmt.Sprint(i))
+	}
+
+	cases := []struct {
+		name  string
+		opts  []Option
+		words []string
+	}{
+		{"empty", nil, nil},
+		{"words", nil, pipeInputs},
+		{"several chunks", nil, many},
+		{"options", []Option{WithProfile(NameProfile), WithLengthBuckets()}, []string{"திரு. முருகன்", "ஜெயா", "hello"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k := New(c.opts...)
+			cols := k.EncodeColumn(c.words)
+			for l := range cols {
+				if len(cols[l]) != len(c.words) {
+					t.Fatalf("key%d column has %d keys, want %d", l, len(cols[l]), len(c.words))
+				}
+			}
+			for i, w := range c.words {
+				k0, k1, k2 := k.Encode(w)
+				if cols[Key0][i] != k0 || cols[Key1][i] != k1 || cols[Key2][i] != k2 {
+					t.Fatalf("keys %d = %q, %q, %q, want %q, %q, %q for %q",
+						i, cols[Key0][i], cols[Key1][i], cols[Key2][i], k0, k1, k2, w)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeColumnProgress(t *testing.T) {
+	var last Progress
+	k := New(WithProgress(func(p Progress) { last = p }))
+	k.EncodeColumn(make([]string, columnChunk+1))
+	if !last.Finished || last.Done != columnChunk+1 {
+		t.Errorf("last report = %+v, want finished with %d done", last, columnChunk+1)
+	}
+}
@@ -0,0 +1,61 @@
+package taphone
+
+import "unicode"
+
+// confusables maps characters that look like Tamil letters or vowel
+// signs to the Tamil characters they are mistaken for. It holds the
+// Tamil digits that Unicode's confusables data lists as lookalikes of
+// Tamil letters and the Malayalam letters and signs that are drawn like
+// their Tamil counterparts.
+var confusables = map[rune]rune{
+	// Tamil digits.
+	'௧': 'க', '௨': 'உ', '௪': 'ச', '௭': 'எ', '௮': 'அ',
+
+	// Malayalam letters and signs.
+	'ട': 'ட', 'ണ': 'ண', 'ള': 'ள', 'ഴ': 'ழ', 'റ': 'ற',
+	'ാ': 'ா', 'െ': 'ெ', 'േ': 'ே', 'ൈ': 'ை', 'ം': 'ஂ',
+}
+
+// FoldConfusables replaces lookalike characters from other scripts, and
+// Tamil digits, that appear inside Tamil words with the Tamil characters
+// they resemble (௧ண்ணன் → கண்ணன்). Lookalikes are only replaced next to
+// Tamil letters or signs, so that numbers and text in other scripts are
+// left intact.
+func FoldConfusables(s string) string {
+	var (
+		rs     = []rune(s)
+		folded = false
+	)
+	// Folding a lookalike may make its neighbour foldable in turn, so
+	// repeat until nothing changes.
+	for changed := true; changed; {
+		changed = false
+		for i, r := range rs {
+			c, ok := confusables[r]
+			if !ok {
+				continue
+			}
+			if (i > 0 && isTamilLetter(rs[i-1])) || (i+1 < len(rs) && isTamilLetter(rs[i+1])) {
+				rs[i] = c
+				changed, folded = true, true
+			}
+		}
+	}
+	if !folded {
+		return s
+	}
+	return string(rs)
+}
+
+// WithConfusables folds lookalike characters in the input to Tamil with
+// FoldConfusables before it is encoded.
+func WithConfusables() Option {
+	return func(k *TAphone) {
+		k.addFilter("confusables", FoldConfusables)
+	}
+}
+
+// isTamilLetter reports whether r is a Tamil letter or sign.
+func isTamilLetter(r rune) bool {
+	return unicode.Is(unicode.Tamil, r) && (unicode.IsLetter(r) || unicode.Is(unicode.M, r))
+}
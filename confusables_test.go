@@ -0,0 +1,35 @@
+package taphone
+
+import "testing"
+
+func TestFoldConfusables(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"tamil digit in a word", "௧ண்ணன்", "கண்ணன்"},
+		{"malayalam letters", "கണ்ണன்", "கண்ணன்"},
+		{"malayalam vowel sign", "கാடு", "காடு"},
+		{"chain of lookalikes", "௧ണ்ணன்", "கண்ணன்"},
+		{"tamil number kept", "௧௨ ரூபாய்", "௧௨ ரூபாய்"},
+		{"malayalam word kept", "കണ്ണൻ", "കണ്ണൻ"},
+		{"lookalike after a space kept", "௧ ரூபாய்", "௧ ரூபாய்"},
+		{"latin", "abc", "abc"},
+		{"empty", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := FoldConfusables(c.input); got != c.want {
+				t.Errorf("FoldConfusables(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithConfusables(t *testing.T) {
+	k := New(WithConfusables())
+	if got, want := k.EncodeLevel("௧ண்ணன்", Key2), k.EncodeLevel("கண்ணன்", Key2); got != want {
+		t.Errorf("EncodeLevel(௧ண்ணன்) = %q, want %q", got, want)
+	}
+}
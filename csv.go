@@ -0,0 +1,83 @@
+package taphone
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// EncodeCSV copies CSV from r to w, appending the three phrase keys
+// (EncodePhrase) of each of the named columns to every row as the columns
+// <column>_key0, <column>_key1 and <column>_key2. The first row of
+// r must be a header; column names are matched case insensitively. Rows
+// are streamed one at a time, so that files of any size can be encoded.
+func (k *TAphone) EncodeCSV(r io.Reader, w io.Writer, columns ...string) error {
+	p, r := k.newProgress(r)
+	var (
+		cr = csv.NewReader(r)
+		cw = csv.NewWriter(w)
+	)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	hdr, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("error reading header: %v", err)
+	}
+	if len(columns) == 0 {
+		return errors.New("no columns to encode")
+	}
+
+	var (
+		cols = make([]int, len(columns))
+		out  = append([]string(nil), hdr...)
+	)
+	for i, c := range columns {
+		cols[i] = -1
+		for j, h := range hdr {
+			if strings.EqualFold(strings.TrimSpace(h), c) {
+				cols[i] = j
+				break
+			}
+		}
+		if cols[i] < 0 {
+			return fmt.Errorf("header has no %s column", c)
+		}
+		out = append(out, c+"_key0", c+"_key1", c+"_key2")
+	}
+	if err := cw.Write(out); err != nil {
+		return err
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// Short rows are padded so that the keys line up with the header.
+		out = append(out[:0], row...)
+		for len(out) < len(hdr) {
+			out = append(out, "")
+		}
+		for _, i := range cols {
+			var k0, k1, k2 string
+			if i < len(row) {
+				k0, k1, k2 = k.EncodePhrase(row[i])
+			}
+			out = append(out, k0, k1, k2)
+		}
+		if err := cw.Write(out); err != nil {
+			return err
+		}
+		p.add(1)
+	}
+	p.finish()
+	cw.Flush()
+	return cw.Error()
+}
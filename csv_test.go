@@ -0,0 +1,56 @@
+package taphone
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncodeCSV(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		columns []string
+		want    string
+		err     string
+	}{
+		{
+			name:    "one column",
+			input:   "id,Name\n1,முருகன்\n2,வணக்கம் நண்பர்கள்\n",
+			columns: []string{"name"},
+			want: "id,Name,name_key0,name_key1,name_key2\n" +
+				"1,முருகன்,MRKN,MRKN1,M4R4KN1\n" +
+				"2,வணக்கம் நண்பர்கள்,VNKKM NNPRKL,VNKKM NNPRKL,VNKKM NNPRKL\n",
+		},
+		{
+			name:    "two columns and a short row",
+			input:   "a,b,c\nமரம்,x,கல்\nமரம்\n",
+			columns: []string{"c", "a"},
+			want: "a,b,c,c_key0,c_key1,c_key2,a_key0,a_key1,a_key2\n" +
+				"மரம்,x,கல்,KL,KL,KL,MRM,MRM,MRM\n" +
+				"மரம்,,,,,,MRM,MRM,MRM\n",
+		},
+		{name: "missing column", input: "id,name\n", columns: []string{"city"}, err: "no city column"},
+		{name: "no columns", input: "id,name\n", err: "no columns"},
+		{name: "no header", input: "", columns: []string{"name"}, err: "error reading header"},
+		{name: "bad quoting", input: "name\n\"முருகன்\n", columns: []string{"name"}, err: "extraneous or missing"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := New().EncodeCSV(strings.NewReader(c.input), &out, c.columns...)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Errorf("EncodeCSV() error = %v, want %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != c.want {
+				t.Errorf("EncodeCSV() = %q, want %q", out.String(), c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package taphone
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// deobfuscateMinRun is the minimum length of a run of identical
+// graphemes, or of spaced out single graphemes, that Deobfuscate
+// treats as stylised. Shorter runs occur in ordinary words (மாமா).
+const deobfuscateMinRun = 3
+
+// regexInterleaved matches punctuation, symbols and emoji between two
+// Tamil characters.
+var regexInterleaved = regexp.MustCompile(`(\p{Tamil})[^\p{L}\p{M}\p{N}\s]+(\p{Tamil})`)
+
+// Deobfuscate normalises stylised or deliberately disguised Tamil text.
+// It strips punctuation, symbols and emoji interleaved inside words
+// (மு.ட்.டா.ள் → முட்டாள்), rejoins words spelt out with spaces between
+// their letters (மு ட் டா ள் → முட்டாள்) and vowel signs separated from
+// their consonants, and collapses runs of three or more identical
+// graphemes to one (வாவாவா → வா). As runs of three or more single letter
+// words are taken to be a spelt out word, it is meant for short, noisy
+// text such as comments and usernames rather than running prose.
+func Deobfuscate(s string) string {
+	// Interleaved punctuation. Matches overlap at their Tamil ends, so
+	// repeat until nothing changes.
+	for {
+		c := regexInterleaved.ReplaceAllString(s, "$1$2")
+		if c == s {
+			break
+		}
+		s = c
+	}
+
+	return collapseGraphemes(rejoinWords(s))
+}
+
+// WithDeobfuscation normalises stylised or disguised text with
+// Deobfuscate before it is encoded.
+func WithDeobfuscation() Option {
+	return func(k *TAphone) {
+		k.addFilter("deobfuscate", Deobfuscate)
+	}
+}
+
+// rejoinWords joins whitespace separated fields of s that are parts of a
+// split word: fields that start with a combining mark are joined to the
+// previous field, and runs of single grapheme Tamil fields are joined
+// together.
+func rejoinWords(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return s
+	}
+
+	var out []string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+
+		// A dangling vowel sign or virama.
+		if r := []rune(f); len(out) > 0 && unicode.Is(unicode.M, r[0]) {
+			out[len(out)-1] += f
+			continue
+		}
+
+		// A run of spaced out letters.
+		j := i
+		for j < len(fields) && isSingleTamilGrapheme(fields[j]) {
+			j++
+		}
+		if j-i >= deobfuscateMinRun {
+			out = append(out, strings.Join(fields[i:j], ""))
+			i = j - 1
+			continue
+		}
+		out = append(out, f)
+	}
+	return strings.Join(out, " ")
+}
+
+func isSingleTamilGrapheme(s string) bool {
+	g := Graphemes(s)
+	if len(g) != 1 {
+		return false
+	}
+	for _, r := range g[0] {
+		return unicode.Is(unicode.Tamil, r) && unicode.IsLetter(r)
+	}
+	return false
+}
+
+// collapseGraphemes collapses runs of deobfuscateMinRun or more identical
+// graphemes in s to one.
+func collapseGraphemes(s string) string {
+	var (
+		g   = Graphemes(s)
+		out strings.Builder
+	)
+	for i := 0; i < len(g); {
+		j := i + 1
+		for j < len(g) && g[j] == g[i] {
+			j++
+		}
+		if j-i >= deobfuscateMinRun {
+			out.WriteString(g[i])
+		} else {
+			out.WriteString(strings.Join(g[i:j], ""))
+		}
+		i = j
+	}
+	return out.String()
+}
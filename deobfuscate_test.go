@@ -0,0 +1,41 @@
+package taphone
+
+import "testing"
+
+func TestDeobfuscate(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"interleaved dots", "மு.ட்.டா.ள்", "முட்டாள்"},
+		{"interleaved symbols and emoji", "பூ😀னை*யா", "பூனையா"},
+		{"spelt out", "மு ட் டா ள்", "முட்டாள்"},
+		{"spelt out inside a sentence", "நீ ஒரு மு ட் டா ள் தான்", "நீ ஒரு முட்டாள் தான்"},
+		{"detached vowel sign", "ம ா மா", "மா மா"},
+		{"detached virama", "கடல ்", "கடல்"},
+		{"run of three", "வாவாவா", "வா"},
+		{"run of two kept", "மாமா", "மாமா"},
+		{"two single letters kept", "நீ வா", "நீ வா"},
+		{"punctuation between words kept", "சரி. போ", "சரி. போ"},
+		{"latin kept", "a.b.c", "a.b.c"},
+		{"empty", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Deobfuscate(c.input); got != c.want {
+				t.Errorf("Deobfuscate(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithDeobfuscation(t *testing.T) {
+	k := New(WithDeobfuscation())
+	if got, want := k.EncodeLevel("வாவாவா", Key2), k.EncodeLevel("வா", Key2); got != want {
+		t.Errorf("EncodeLevel(வாவாவா) = %q, want %q", got, want)
+	}
+	if r := k.Rules(); len(r.Filters) != 1 || r.Filters[0] != "deobfuscate" {
+		t.Errorf("Rules().Filters = %q, want [deobfuscate]", r.Filters)
+	}
+}
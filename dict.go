@@ -0,0 +1,222 @@
+package taphone
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultWordlist is a moderately sized frequency ordered Tamil wordlist
+// that ships with the package.
+//
+//go:embed data/wordlist.txt
+var defaultWordlist string
+
+var (
+	defaultDict     *Dictionary
+	defaultDictOnce sync.Once
+)
+
+// Dictionary is a frequency weighted Tamil wordlist indexed by the
+// phonetic keys of its words. It is safe for concurrent use.
+type Dictionary struct {
+	enc    *TAphone
+	layout *KeyboardLayout
+
+	mu    sync.RWMutex
+	words map[string]*entry
+	index [3]map[string][]*entry
+	total int
+
+	// sorted holds the distinct key0s in sorted order for prefix
+	// searches. It is rebuilt lazily when dirty.
+	sorted []string
+	dirty  bool
+}
+
+type entry struct {
+	word string
+	freq int
+	keys [3]string
+}
+
+// NewDictionary returns an empty dictionary that keys its words
+// with the given encoder.
+func NewDictionary(enc *TAphone) *Dictionary {
+	d := &Dictionary{
+		enc:   enc,
+		words: make(map[string]*entry),
+	}
+	for i := range d.index {
+		d.index[i] = make(map[string][]*entry)
+	}
+	return d
+}
+
+// DefaultDictionary returns a dictionary loaded with the embedded
+// default wordlist. The list is parsed lazily on the first call and
+// the same instance is returned thereafter.
+func DefaultDictionary() *Dictionary {
+	defaultDictOnce.Do(func() {
+		defaultDict = NewDictionary(New())
+		if err := defaultDict.Load(strings.NewReader(defaultWordlist)); err != nil {
+			panic("taphone: invalid embedded wordlist: " + err.Error())
+		}
+	})
+	return defaultDict
+}
+
+// Add adds a word to the dictionary with the given frequency. Adding a
+// word that already exists increments its frequency.
+func (d *Dictionary) Add(word string, freq int) {
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if e, ok := d.words[word]; ok {
+		e.freq += freq
+		d.total += freq
+		return
+	}
+
+	e := &entry{word: word, freq: freq, keys: d.enc.keys(word)}
+	if e.keys[Key0] == "" {
+		return
+	}
+	d.words[word] = e
+	d.total += freq
+	if len(d.index[Key0][e.keys[Key0]]) == 0 {
+		d.dirty = true
+	}
+	for l, key := range e.keys {
+		d.index[l][key] = append(d.index[l][key], e)
+	}
+}
+
+// Load reads a wordlist into the dictionary. Each line holds a word,
+// optionally followed by whitespace and its frequency. Blank lines and
+// lines starting with # are ignored. Words without a frequency are
+// counted once.
+func (d *Dictionary) Load(r io.Reader) error {
+	p, r := d.enc.newProgress(r)
+	var (
+		sc = bufio.NewScanner(r)
+		n  = 0
+	)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		n++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		freq := 1
+		if len(fields) > 1 {
+			f, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return fmt.Errorf("line %d: invalid frequency %q", n, fields[1])
+			}
+			freq = f
+		}
+		d.Add(fields[0], freq)
+		p.add(1)
+	}
+	p.finish()
+	return sc.Err()
+}
+
+// Save writes the words of the dictionary to w in the format read by
+// Load, one word and its frequency per line, in descending order of
+// frequency.
+func (d *Dictionary) Save(w io.Writer) error {
+	d.mu.RLock()
+	entries := make([]*entry, 0, len(d.words))
+	for _, e := range d.words {
+		entries = append(entries, e)
+	}
+	d.mu.RUnlock()
+	sortEntries(entries)
+
+	bw := bufio.NewWriter(w)
+	for _, e := range entries {
+		if _, err := fmt.Fprintf(bw, "%s\t%d\n", e.word, e.freq); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// SetKeyboard sets the keyboard layout whose key adjacencies are used to
+// suggest corrections for fat-finger typos. A nil layout disables typo
+// suggestions.
+func (d *Dictionary) SetKeyboard(l *KeyboardLayout) {
+	d.mu.Lock()
+	d.layout = l
+	d.mu.Unlock()
+}
+
+// Len returns the number of words in the dictionary.
+func (d *Dictionary) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.words)
+}
+
+// Contains reports whether word is in the dictionary.
+func (d *Dictionary) Contains(word string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.words[word]
+	return ok
+}
+
+// Freq returns the frequency of word, or 0 if it isn't in the dictionary.
+func (d *Dictionary) Freq(word string) int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if e, ok := d.words[word]; ok {
+		return e.freq
+	}
+	return 0
+}
+
+// Lookup returns the dictionary words that share the given key level
+// with word, in descending order of frequency.
+func (d *Dictionary) Lookup(word string, level KeyLevel) []string {
+	key := d.enc.keys(word)[level]
+	if key == "" {
+		return nil
+	}
+
+	d.mu.RLock()
+	entries := append([]*entry(nil), d.index[level][key]...)
+	d.mu.RUnlock()
+
+	sortEntries(entries)
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.word
+	}
+	return out
+}
+
+// sortEntries sorts entries by descending frequency, breaking ties by word.
+func sortEntries(entries []*entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].freq != entries[j].freq {
+			return entries[i].freq > entries[j].freq
+		}
+		return entries[i].word < entries[j].word
+	})
+}
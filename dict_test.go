@@ -0,0 +1,137 @@
+package taphone
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDictionary(t *testing.T) {
+	d := NewDictionary(New())
+	d.Add("கடல்", 5)
+	d.Add("கடள்", 1)
+	d.Add("கடன்", 3)
+	d.Add(" மலை ", 4)
+	d.Add("கடல்", 2)
+	d.Add("", 9)
+	d.Add("hello", 9)
+
+	if d.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", d.Len())
+	}
+
+	freqs := []struct {
+		word string
+		freq int
+		ok   bool
+	}{
+		{"கடல்", 7, true},
+		{"மலை", 4, true},
+		{"கடன்", 3, true},
+		{"மழை", 0, false},
+		{"hello", 0, false},
+	}
+	for _, c := range freqs {
+		if got := d.Freq(c.word); got != c.freq {
+			t.Errorf("Freq(%q) = %d, want %d", c.word, got, c.freq)
+		}
+		if got := d.Contains(c.word); got != c.ok {
+			t.Errorf("Contains(%q) = %v, want %v", c.word, got, c.ok)
+		}
+	}
+
+	lookups := []struct {
+		word  string
+		level KeyLevel
+		want  []string
+	}{
+		{"கடல்", Key0, []string{"கடல்", "கடள்"}},
+		{"கடல்", Key2, []string{"கடல்", "கடள்"}},
+		{"கடன்", Key1, []string{"கடன்"}},
+		{"மழை", Key2, nil},
+		{"", Key0, nil},
+	}
+	for _, c := range lookups {
+		got := d.Lookup(c.word, c.level)
+		if len(got) == 0 && len(c.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Lookup(%q, %d) = %q, want %q", c.word, c.level, got, c.want)
+		}
+	}
+}
+
+func TestDictionaryLoad(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  map[string]int
+		ok    bool
+	}{
+		{"frequencies", "கடல்\t5\nமலை 2\n", map[string]int{"கடல்": 5, "மலை": 2}, true},
+		{"default frequency", "கடல்\n", map[string]int{"கடல்": 1}, true},
+		{"comments and blanks", "# words\n\n  கடல் 3  \n", map[string]int{"கடல்": 3}, true},
+		{"repeated words", "கடல் 3\nகடல் 4\n", map[string]int{"கடல்": 7}, true},
+		{"invalid frequency", "கடல் many\n", nil, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := NewDictionary(New())
+			err := d.Load(strings.NewReader(c.input))
+			if (err == nil) != c.ok {
+				t.Fatalf("Load() error = %v, want ok %v", err, c.ok)
+			}
+			for w, f := range c.want {
+				if d.Freq(w) != f {
+					t.Errorf("Freq(%q) = %d, want %d", w, d.Freq(w), f)
+				}
+			}
+			if c.ok && d.Len() != len(c.want) {
+				t.Errorf("Len() = %d, want %d", d.Len(), len(c.want))
+			}
+		})
+	}
+}
+
+func TestDictionarySave(t *testing.T) {
+	d := NewDictionary(New())
+	d.Add("மலை", 2)
+	d.Add("கடல்", 5)
+	d.Add("கடன்", 2)
+
+	var buf bytes.Buffer
+	if err := d.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "கடல்\t5\nகடன்\t2\nமலை\t2\n"; got != want {
+		t.Errorf("Save() = %q, want %q", got, want)
+	}
+
+	r := NewDictionary(New())
+	if err := r.Load(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != 3 || r.Freq("கடல்") != 5 {
+		t.Errorf("Load(Save()) has %d words, கடல் %d", r.Len(), r.Freq("கடல்"))
+	}
+}
+
+func TestDefaultDictionary(t *testing.T) {
+	d := DefaultDictionary()
+	if d != DefaultDictionary() {
+		t.Error("DefaultDictionary() returned different instances")
+	}
+	if d.Len() < 500 {
+		t.Errorf("Len() = %d, want the embedded wordlist", d.Len())
+	}
+	for _, w := range []string{"மற்றும்", "தமிழ்", "வணக்கம்"} {
+		if !d.Contains(w) {
+			t.Errorf("Contains(%q) = false", w)
+		}
+	}
+	if d.Freq("மற்றும்") <= d.Freq("தமிழ்") {
+		t.Errorf("Freq(மற்றும்) = %d, not above Freq(தமிழ்) = %d", d.Freq("மற்றும்"), d.Freq("தமிழ்"))
+	}
+}
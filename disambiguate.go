@@ -0,0 +1,49 @@
+package taphone
+
+import "strconv"
+
+// disambiguateMaxCodes is the maximum number of codes in key0 of the
+// short keys that WithDisambiguation disambiguates.
+const disambiguateMaxCodes = 2
+
+// WithDisambiguation appends a disambiguator to the key1 and key2 keys of
+// short words, whose key0 has at most two codes. Short keys are shared
+// by many common words (கலை, கல், காலை, கால், கேள் → KL) and make for
+// very large buckets in big indexes. The disambiguator is a colon
+// followed by the word's syllable count and its final consonant's code,
+// or V if it ends in a vowel (கல் → KL:1L, கலை → KL:2V). key0 is left as
+// is for broad matching.
+func WithDisambiguation() Option {
+	return func(k *TAphone) {
+		k.disambiguate = true
+	}
+}
+
+// disambiguator returns the disambiguator of a word with the given key0,
+// or "" if the key is long enough not to need one.
+func disambiguator(word, key0 string) string {
+	t, ok := tokenizeKey(key0)
+	if !ok || len(t) > disambiguateMaxCodes {
+		return ""
+	}
+
+	syls := syllables(word)
+	if len(syls) == 0 {
+		return ""
+	}
+
+	n := 0
+	for _, s := range syls {
+		if s.vowel != 0 {
+			n++
+		}
+	}
+
+	final := "V"
+	if last := syls[len(syls)-1]; last.vowel == 0 {
+		if c, ok := consonants[string(last.cons)]; ok {
+			final = c
+		}
+	}
+	return ":" + strconv.Itoa(n) + final
+}
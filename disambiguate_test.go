@@ -0,0 +1,43 @@
+package taphone
+
+import "testing"
+
+func TestWithDisambiguation(t *testing.T) {
+	cases := []struct {
+		input string
+		keys  [3]string
+	}{
+		{"கல்", [3]string{"KL", "KL:1L", "KL:1L"}},
+		{"கலை", [3]string{"KL", "KL:2V", "KL6:2V"}},
+		{"காலை", [3]string{"KL", "KL:2V", "KL6:2V"}},
+		{"கேள்", [3]string{"KL", "KL:1L", "K5L:1L"}},
+		{"அ", [3]string{"A", "A:1V", "A:1V"}},
+		{"மரம்", [3]string{"MRM", "MRM", "MRM"}},
+		{"வணக்கம்", [3]string{"VNKKM", "VNKKM", "VNKKM"}},
+	}
+	k := New(WithDisambiguation())
+	for _, c := range cases {
+		var got [3]string
+		got[0], got[1], got[2] = k.Encode(c.input)
+		if got != c.keys {
+			t.Errorf("Encode(%q) = %q, want %q", c.input, got, c.keys)
+		}
+	}
+}
+
+func TestDisambiguator(t *testing.T) {
+	cases := []struct {
+		word, key0 string
+		want       string
+	}{
+		{"கல்", "KL", ":1L"},
+		{"கலை", "KL", ":2V"},
+		{"மரம்", "MRM", ""},
+		{"hello", "", ""},
+	}
+	for _, c := range cases {
+		if got := disambiguator(c.word, c.key0); got != c.want {
+			t.Errorf("disambiguator(%q, %q) = %q, want %q", c.word, c.key0, got, c.want)
+		}
+	}
+}
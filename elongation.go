@@ -0,0 +1,96 @@
+package taphone
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// vowelQuality maps vowels to the short vowel of their quality. An
+// elongated vowel is followed by vowels of its quality (போஓஓ, கைஇ).
+var vowelQuality = map[rune]rune{
+	'அ': 'அ', 'ஆ': 'அ', 'இ': 'இ', 'ஈ': 'இ', 'உ': 'உ', 'ஊ': 'உ', 'எ': 'எ',
+	'ஏ': 'எ', 'ஐ': 'இ', 'ஒ': 'ஒ', 'ஓ': 'ஒ', 'ஔ': 'உ',
+}
+
+// signVowels maps vowel signs to their vowels.
+var signVowels = func() map[string]rune {
+	out := make(map[string]rune, len(vowelSigns))
+	for v, s := range vowelSigns {
+		if s != "" {
+			out[s] = v
+		}
+	}
+	return out
+}()
+
+// CollapseElongation collapses vowels elongated for emphasis in informal
+// writing to their plain spelling: repeated vowel signs (வாாா → வா),
+// vowel letters that repeat the vowel before them (வாஆஆ → வா, போஓ → போ)
+// and runs of three or more identical graphemes (சூப்பர்ர்ர் → சூப்பர்).
+func CollapseElongation(s string) string {
+	var (
+		g   = Graphemes(s)
+		out = make([]string, 0, len(g))
+	)
+	for _, c := range g {
+		c = dedupeMarks(c)
+		if len(out) > 0 && echoesVowel(c, out[len(out)-1]) {
+			continue
+		}
+		out = append(out, c)
+	}
+	return collapseGraphemes(strings.Join(out, ""))
+}
+
+// WithElongationCollapse collapses elongated vowels in the input with
+// CollapseElongation before it is encoded.
+func WithElongationCollapse() Option {
+	return func(k *TAphone) {
+		k.addFilter("elongation", CollapseElongation)
+	}
+}
+
+// dedupeMarks removes combining marks of a grapheme that repeat the
+// mark before them.
+func dedupeMarks(g string) string {
+	var (
+		b    strings.Builder
+		prev rune
+	)
+	for _, r := range g {
+		if r == prev && unicode.Is(unicode.M, r) {
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
+
+// echoesVowel reports whether grapheme g is a vowel letter of the same
+// quality as the vowel that grapheme prev ends in.
+func echoesVowel(g, prev string) bool {
+	v, _ := utf8.DecodeRuneInString(g)
+	q, ok := vowelQuality[v]
+	if !ok || len(g) != utf8.RuneLen(v) {
+		return false
+	}
+	return q == vowelQuality[graphemeVowel(prev)]
+}
+
+// graphemeVowel returns the vowel that grapheme g ends in, or 0 if it
+// ends in a virama or isn't Tamil.
+func graphemeVowel(g string) rune {
+	r, n := utf8.DecodeRuneInString(g)
+	if _, ok := vowelQuality[r]; ok {
+		return r
+	}
+	if !isConsonant(string(r)) {
+		return 0
+	}
+	if n == len(g) {
+		return 'அ'
+	}
+	return signVowels[g[n:]]
+}
@@ -0,0 +1,38 @@
+package taphone
+
+import "testing"
+
+func TestCollapseElongation(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"repeated signs", "வாாா", "வா"},
+		{"echoed long vowel", "வாஆஆ", "வா"},
+		{"echoed short vowel", "போஓ", "போ"},
+		{"echoed inherent vowel", "சரிஇ அவனஅ", "சரி அவன"},
+		{"echoed ai", "கைஇஇ", "கை"},
+		{"repeated graphemes", "சூப்பர்ர்ர்", "சூப்பர்"},
+		{"repeated letters", "ஆஆஆஆ", "ஆ"},
+		{"other quality", "வாஇ", "வாஇ"},
+		{"virama", "கண்ணஅ", "கண்ண"},
+		{"echoed initial vowel", "அஅம்மா", "அம்மா"},
+		{"geminates are kept", "அக்கா மாமா", "அக்கா மாமா"},
+		{"latin", "sooo good", "so good"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := CollapseElongation(c.input); got != c.want {
+				t.Errorf("CollapseElongation(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithElongationCollapse(t *testing.T) {
+	k := New(WithElongationCollapse())
+	if got, want := k.EncodeLevel("சூப்பர்ர்ர்ர்", Key2), New().EncodeLevel("சூப்பர்", Key2); got != want {
+		t.Errorf("key2 = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,166 @@
+// Package eval measures how well taphone keys match labelled pairs of
+// words, so that key levels and encoder options can be chosen with data.
+// Two words are predicted to match at a level if their keys at that
+// level are equal.
+package eval
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cmrajan/taphone"
+)
+
+// Pair is a labelled pair of words.
+type Pair struct {
+	A, B  string
+	Match bool
+}
+
+// Counts is a confusion matrix.
+type Counts struct {
+	TP, FP, TN, FN int
+}
+
+// Precision returns the share of predicted matches that are matches.
+func (c Counts) Precision() float64 {
+	return ratio(c.TP, c.TP+c.FP)
+}
+
+// Recall returns the share of matches that are predicted.
+func (c Counts) Recall() float64 {
+	return ratio(c.TP, c.TP+c.FN)
+}
+
+// F1 returns the harmonic mean of precision and recall.
+func (c Counts) F1() float64 {
+	p, r := c.Precision(), c.Recall()
+	if p+r == 0 {
+		return 0
+	}
+	return 2 * p * r / (p + r)
+}
+
+// Accuracy returns the share of pairs predicted correctly.
+func (c Counts) Accuracy() float64 {
+	return ratio(c.TP+c.TN, c.TP+c.FP+c.TN+c.FN)
+}
+
+// Level is the evaluation of one key level.
+type Level struct {
+	Counts
+
+	// FalsePositives and FalseNegatives are the misclassified pairs.
+	FalsePositives []Pair
+	FalseNegatives []Pair
+}
+
+// Report is the evaluation of an encoder configuration.
+type Report struct {
+	Name   string
+	Levels [3]Level
+}
+
+// Config is a named set of encoder options to evaluate.
+type Config struct {
+	Name    string
+	Options []taphone.Option
+}
+
+// Evaluate evaluates the keys of an encoder on labelled pairs at each
+// key level.
+func Evaluate(enc *taphone.TAphone, pairs []Pair) Report {
+	var r Report
+	for _, p := range pairs {
+		var a, b [3]string
+		a[0], a[1], a[2] = enc.Encode(p.A)
+		b[0], b[1], b[2] = enc.Encode(p.B)
+
+		for l := range r.Levels {
+			lv := &r.Levels[l]
+			pred := a[l] != "" && a[l] == b[l]
+			switch {
+			case pred && p.Match:
+				lv.TP++
+			case pred:
+				lv.FP++
+				lv.FalsePositives = append(lv.FalsePositives, p)
+			case p.Match:
+				lv.FN++
+				lv.FalseNegatives = append(lv.FalseNegatives, p)
+			default:
+				lv.TN++
+			}
+		}
+	}
+	return r
+}
+
+// Compare evaluates each configuration on labelled pairs.
+func Compare(configs []Config, pairs []Pair) []Report {
+	out := make([]Report, len(configs))
+	for i, c := range configs {
+		out[i] = Evaluate(taphone.New(c.Options...), pairs)
+		out[i].Name = c.Name
+	}
+	return out
+}
+
+// ReadPairs reads labelled pairs from CSV rows of two words and a label.
+// Labels 1, true, yes, y and match mark matches and all others mark
+// non-matches. A first row whose label isn't one of these or 0, false,
+// no, n or nonmatch is taken to be a header and skipped.
+func ReadPairs(r io.Reader) ([]Pair, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+
+	var out []Pair
+	for n := 0; ; n++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		label := strings.ToLower(strings.TrimSpace(row[2]))
+		match := false
+		switch label {
+		case "1", "true", "yes", "y", "match":
+			match = true
+		case "0", "false", "no", "n", "nonmatch":
+		default:
+			if n == 0 {
+				continue
+			}
+		}
+		out = append(out, Pair{A: strings.TrimSpace(row[0]), B: strings.TrimSpace(row[1]), Match: match})
+	}
+	return out, nil
+}
+
+// WriteTable writes reports as a table of precision, recall and F1 per
+// configuration and key level.
+func WriteTable(w io.Writer, reports []Report) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "config\tlevel\tTP\tFP\tTN\tFN\tprecision\trecall\tF1")
+	for _, r := range reports {
+		for l, lv := range r.Levels {
+			fmt.Fprintf(tw, "%s\tkey%d\t%d\t%d\t%d\t%d\t%.3f\t%.3f\t%.3f\n",
+				r.Name, l, lv.TP, lv.FP, lv.TN, lv.FN, lv.Precision(), lv.Recall(), lv.F1())
+		}
+	}
+	return tw.Flush()
+}
+
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
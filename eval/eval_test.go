@@ -0,0 +1,126 @@
+package eval
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+func TestCounts(t *testing.T) {
+	cases := []struct {
+		name                       string
+		c                          Counts
+		precision, recall, f1, acc float64
+	}{
+		{"perfect", Counts{TP: 2, TN: 2}, 1, 1, 1, 1},
+		{"mixed", Counts{TP: 3, FP: 1, TN: 4, FN: 2}, 0.75, 0.6, 2.0 / 3, 0.7},
+		{"no predictions", Counts{TN: 2, FN: 2}, 0, 0, 0, 0.5},
+		{"empty", Counts{}, 0, 0, 0, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := []float64{c.c.Precision(), c.c.Recall(), c.c.F1(), c.c.Accuracy()}
+			want := []float64{c.precision, c.recall, c.f1, c.acc}
+			for i := range got {
+				if math.Abs(got[i]-want[i]) > 1e-9 {
+					t.Errorf("%+v: precision, recall, F1, accuracy = %v, want %v", c.c, got, want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	pairs := []Pair{
+		{"கொடு", "கடு", false},
+		{"கரம்", "கறம்", true},
+		{"முருகன்", "முருகன்", true},
+		{"முருகன்", "செல்வம்", false},
+		{"hello", "hello", true},
+	}
+	r := Evaluate(taphone.New(), pairs)
+
+	want := [3]Counts{
+		{TP: 2, FP: 1, TN: 1, FN: 1},
+		{TP: 1, FP: 1, TN: 1, FN: 2},
+		{TP: 1, FP: 0, TN: 2, FN: 2},
+	}
+	for l, lv := range r.Levels {
+		if lv.Counts != want[l] {
+			t.Errorf("key%d counts = %+v, want %+v", l, lv.Counts, want[l])
+		}
+		if len(lv.FalsePositives) != lv.FP || len(lv.FalseNegatives) != lv.FN {
+			t.Errorf("key%d has %d false positives and %d false negatives listed, want %d and %d",
+				l, len(lv.FalsePositives), len(lv.FalseNegatives), lv.FP, lv.FN)
+		}
+	}
+	if fp := r.Levels[taphone.Key0].FalsePositives; len(fp) != 1 || fp[0] != pairs[0] {
+		t.Errorf("key0 false positives = %v, want %v", fp, pairs[:1])
+	}
+}
+
+func TestCompare(t *testing.T) {
+	pairs := []Pair{{"ஜெயா", "செயா", true}}
+	reports := Compare([]Config{
+		{Name: "default"},
+		{Name: "names", Options: []taphone.Option{taphone.WithProfile(taphone.NameProfile)}},
+	}, pairs)
+	if len(reports) != 2 || reports[0].Name != "default" || reports[1].Name != "names" {
+		t.Fatalf("Compare() = %+v, want reports named default and names", reports)
+	}
+	if tp := reports[1].Levels[taphone.Key2].TP; tp != 1 {
+		t.Errorf("names key2 TP = %d, want 1", tp)
+	}
+}
+
+func TestReadPairs(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  []Pair
+		err   bool
+	}{
+		{"labels", "a,b,1\nc, d ,no\ne,f,Match\ng,h,?\n", []Pair{
+			{"a", "b", true}, {"c", "d", false}, {"e", "f", true}, {"g", "h", false},
+		}, false},
+		{"header", "a,b,label\nc,d,y\n", []Pair{{"c", "d", true}}, false},
+		{"no header", "a,b,0\n", []Pair{{"a", "b", false}}, false},
+		{"wrong columns", "a,b\n", nil, true},
+		{"empty", "", nil, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := ReadPairs(strings.NewReader(c.input))
+			if (err != nil) != c.err {
+				t.Fatalf("ReadPairs() error = %v, want error %v", err, c.err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("ReadPairs() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var b bytes.Buffer
+	r := Report{Name: "default"}
+	r.Levels[0].Counts = Counts{TP: 3, FP: 1, TN: 4, FN: 2}
+	if err := WriteTable(&b, []Report{r}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("WriteTable() wrote %d lines, want 4:\n%s", len(lines), b.String())
+	}
+	if got := strings.Fields(lines[1]); !reflect.DeepEqual(got, []string{
+		"default", "key0", "3", "1", "4", "2", "0.750", "0.600", "0.667",
+	}) {
+		t.Errorf("key0 row = %q", got)
+	}
+}
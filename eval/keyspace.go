@@ -0,0 +1,221 @@
+package eval
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cmrajan/taphone"
+)
+
+// Keyspace describes the distribution of keys at one level over a corpus.
+type Keyspace struct {
+	Level taphone.KeyLevel
+
+	// Words and Keys are the number of distinct words and keys.
+	Words int
+	Keys  int
+
+	// Entropy is the Shannon entropy of the keys of the corpus' word
+	// occurrences in bits, and WordEntropy that of the words. Their
+	// difference, Ambiguity, is the average information lost by keying a
+	// word: 0 if every word has a key of its own.
+	Entropy     float64
+	WordEntropy float64
+	Ambiguity   float64
+
+	// Collisions is the number of distinct words that share their key
+	// with another word.
+	Collisions int
+
+	// Buckets are the keys shared by more than one word, largest first.
+	Buckets []Bucket
+
+	// Merges are the character differences between colliding words,
+	// most frequent first. They point at the rules and codes that
+	// contribute most to collisions.
+	Merges []Merge
+}
+
+// Bucket is a key and the distinct words that share it, most frequent
+// first.
+type Bucket struct {
+	Key   string
+	Words []string
+}
+
+// Merge is a difference between words that the keys don't distinguish:
+// a pair of substituted characters (ண/ந) or an inserted character (+்).
+type Merge struct {
+	Diff  string
+	Count int
+}
+
+// AnalyzeKeyspace measures the keys of a corpus of words at a level.
+// Repeated words count as occurrences for the entropies. Collisions are
+// broken down into Merges by aligning each word in a bucket with the
+// bucket's most frequent word.
+func AnalyzeKeyspace(enc *taphone.TAphone, words []string, level taphone.KeyLevel) Keyspace {
+	var (
+		freq  = make(map[string]int)
+		keys  = make(map[string]int)
+		wkey  = make(map[string]string)
+		total int
+	)
+	for _, w := range words {
+		k := enc.EncodeLevel(w, level)
+		if k == "" {
+			continue
+		}
+		freq[w]++
+		keys[k]++
+		wkey[w] = k
+		total++
+	}
+
+	ks := Keyspace{
+		Level:       level,
+		Words:       len(freq),
+		Keys:        len(keys),
+		Entropy:     entropy(keys, total),
+		WordEntropy: entropy(freq, total),
+	}
+	ks.Ambiguity = ks.WordEntropy - ks.Entropy
+
+	// Group words by key.
+	buckets := make(map[string][]string)
+	for w, k := range wkey {
+		buckets[k] = append(buckets[k], w)
+	}
+
+	merges := make(map[string]int)
+	for k, ws := range buckets {
+		if len(ws) < 2 {
+			continue
+		}
+		sort.Slice(ws, func(i, j int) bool {
+			if freq[ws[i]] != freq[ws[j]] {
+				return freq[ws[i]] > freq[ws[j]]
+			}
+			return ws[i] < ws[j]
+		})
+		ks.Buckets = append(ks.Buckets, Bucket{Key: k, Words: ws})
+		ks.Collisions += len(ws)
+
+		for _, w := range ws[1:] {
+			for _, d := range diff([]rune(ws[0]), []rune(w)) {
+				merges[d]++
+			}
+		}
+	}
+
+	sort.Slice(ks.Buckets, func(i, j int) bool {
+		a, b := ks.Buckets[i], ks.Buckets[j]
+		if len(a.Words) != len(b.Words) {
+			return len(a.Words) > len(b.Words)
+		}
+		return a.Key < b.Key
+	})
+	for d, n := range merges {
+		ks.Merges = append(ks.Merges, Merge{Diff: d, Count: n})
+	}
+	sort.Slice(ks.Merges, func(i, j int) bool {
+		a, b := ks.Merges[i], ks.Merges[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Diff < b.Diff
+	})
+	return ks
+}
+
+// entropy returns the Shannon entropy in bits of a distribution of
+// counts summing to total.
+func entropy(counts map[string]int, total int) float64 {
+	var h float64
+	for _, n := range counts {
+		p := float64(n) / float64(total)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// diff aligns a and b with a minimal edit script and returns its edits:
+// "x/y" for substitutions and "+x" for characters present in only one
+// of the words. Substitution pairs are ordered so that x < y.
+func diff(a, b []rune) []string {
+	var out []string
+	for _, e := range align(a, b) {
+		switch {
+		case e.a == e.b:
+		case e.a != 0 && e.b != 0:
+			x, y := string(e.a), string(e.b)
+			if y < x {
+				x, y = y, x
+			}
+			out = append(out, x+"/"+y)
+		case e.a != 0:
+			out = append(out, "+"+string(e.a))
+		default:
+			out = append(out, "+"+string(e.b))
+		}
+	}
+	return out
+}
+
+// edit is a step of an alignment: a pair of equal or substituted
+// characters, or a character of one word only, with 0 for the other.
+type edit struct {
+	a, b rune
+}
+
+// align aligns a and b with a minimal edit script and returns its steps
+// in order.
+func align(a, b []rune) []edit {
+	// d[i][j] is the edit distance between a[:i] and b[:j].
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			c := 1
+			if a[i-1] == b[j-1] {
+				c = 0
+			}
+			d[i][j] = min(d[i-1][j-1]+c, min(d[i-1][j]+1, d[i][j-1]+1))
+		}
+	}
+
+	var out []edit
+	for i, j := len(a), len(b); i > 0 || j > 0; {
+		switch {
+		case i > 0 && j > 0 && a[i-1] == b[j-1] && d[i][j] == d[i-1][j-1]:
+			out = append(out, edit{a[i-1], b[j-1]})
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && d[i][j] == d[i-1][j-1]+1:
+			out = append(out, edit{a[i-1], b[j-1]})
+			i, j = i-1, j-1
+		case i > 0 && d[i][j] == d[i-1][j]+1:
+			out = append(out, edit{a: a[i-1]})
+			i--
+		default:
+			out = append(out, edit{b: b[j-1]})
+			j--
+		}
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,53 @@
+package eval
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+func TestAnalyzeKeyspace(t *testing.T) {
+	words := []string{"முருகன்", "முருகன்", "முருகண்", "செல்வம்", "hello"}
+
+	ks := AnalyzeKeyspace(taphone.New(), words, taphone.Key0)
+	if ks.Words != 3 || ks.Keys != 2 || ks.Collisions != 2 {
+		t.Errorf("words, keys, collisions = %d, %d, %d, want 3, 2, 2", ks.Words, ks.Keys, ks.Collisions)
+	}
+	entropy := -(0.75*math.Log2(0.75) + 0.25*math.Log2(0.25))
+	if math.Abs(ks.Entropy-entropy) > 1e-9 || math.Abs(ks.WordEntropy-1.5) > 1e-9 ||
+		math.Abs(ks.Ambiguity-(1.5-entropy)) > 1e-9 {
+		t.Errorf("entropy, word entropy, ambiguity = %v, %v, %v, want %v, 1.5, %v",
+			ks.Entropy, ks.WordEntropy, ks.Ambiguity, entropy, 1.5-entropy)
+	}
+	if want := []Bucket{{Key: "MRKN", Words: []string{"முருகன்", "முருகண்"}}}; !reflect.DeepEqual(ks.Buckets, want) {
+		t.Errorf("buckets = %v, want %v", ks.Buckets, want)
+	}
+	if want := []Merge{{Diff: "ண/ன", Count: 1}}; !reflect.DeepEqual(ks.Merges, want) {
+		t.Errorf("merges = %v, want %v", ks.Merges, want)
+	}
+
+	ks = AnalyzeKeyspace(taphone.New(), words, taphone.Key2)
+	if ks.Collisions != 0 || ks.Ambiguity != 0 || ks.Buckets != nil {
+		t.Errorf("key2 collisions, ambiguity, buckets = %d, %v, %v, want none", ks.Collisions, ks.Ambiguity, ks.Buckets)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want []string
+	}{
+		{"முருகன்", "முருகன்", nil},
+		{"முருகன்", "முருகண்", []string{"ண/ன"}},
+		{"முருகண்", "முருகன்", []string{"ண/ன"}},
+		{"படம்", "பட்டம்", []string{"+ட", "+்"}},
+		{"", "அ", []string{"+அ"}},
+	}
+	for _, c := range cases {
+		if got := diff([]rune(c.a), []rune(c.b)); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("diff(%q, %q) = %q, want %q", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package eval
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/cmrajan/taphone"
+)
+
+const (
+	// ruleMinSupport is the number of mismatched pairs a difference must
+	// occur in to be considered systematic.
+	ruleMinSupport = 2
+
+	// ruleMaxCandidates is the maximum number of differences, most
+	// frequent first, that are evaluated as rules.
+	ruleMaxCandidates = 50
+)
+
+// RuleSuggestion is a candidate rule that replaces From with To in the
+// input before it is encoded, with its projected effect on the labelled
+// pairs at a key level. Rules fold a letter into another (ழ → ள), rewrite
+// a compound (க்ஷ → ட்ச) or drop a mark (த்த → த).
+type RuleSuggestion struct {
+	From, To string
+
+	// Support is the number of matching pairs that the keys miss and
+	// whose words differ by the rule.
+	Support int
+
+	// Before and After are the counts without and with the rule.
+	Before, After Counts
+}
+
+// Profile returns the rule as a profile that can be passed to
+// taphone.WithProfile.
+func (s RuleSuggestion) Profile() taphone.Profile {
+	return taphone.Profile{
+		Name:    "rule:" + s.From + ">" + s.To,
+		Replace: [][2]string{{s.From, s.To}},
+	}
+}
+
+// SuggestRules finds the systematic differences between the words of
+// matching pairs that the keys at level miss with the given encoder
+// options, and returns up to n rules, or all if n < 1, that would
+// improve F1 at level if added to the options, best first. Differences
+// are found by aligning the letters of the words, and each is evaluated
+// as a replacement in both directions on all pairs, so the projected
+// precision and recall account for the non-matching pairs that a rule
+// would merge.
+func SuggestRules(opts []taphone.Option, pairs []Pair, level taphone.KeyLevel, n int) []RuleSuggestion {
+	before := Evaluate(taphone.New(opts...), pairs).Levels[level]
+
+	support := make(map[[2]string]int)
+	for _, p := range before.FalseNegatives {
+		seen := make(map[[2]string]bool)
+		for _, s := range segments([]rune(p.A), []rune(p.B)) {
+			if !seen[s] {
+				seen[s] = true
+				support[s]++
+			}
+		}
+	}
+
+	var cands [][2]string
+	for s, c := range support {
+		if c >= ruleMinSupport {
+			cands = append(cands, s)
+		}
+	}
+	sort.Slice(cands, func(i, j int) bool {
+		a, b := cands[i], cands[j]
+		if support[a] != support[b] {
+			return support[a] > support[b]
+		}
+		return a[0]+a[1] < b[0]+b[1]
+	})
+	if len(cands) > ruleMaxCandidates {
+		cands = cands[:ruleMaxCandidates]
+	}
+
+	var out []RuleSuggestion
+	for _, c := range cands {
+		var best *RuleSuggestion
+		for _, r := range [][2]string{{c[0], c[1]}, {c[1], c[0]}} {
+			s := RuleSuggestion{From: r[0], To: r[1], Support: support[c], Before: before.Counts}
+			enc := taphone.New(append(opts[:len(opts):len(opts)], taphone.WithProfile(s.Profile()))...)
+			s.After = Evaluate(enc, pairs).Levels[level].Counts
+			if best == nil || s.After.F1() > best.After.F1() {
+				best = &s
+			}
+		}
+		if best.After.F1() > before.F1() {
+			out = append(out, *best)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].After.F1() > out[j].After.F1()
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// WriteRules writes rule suggestions as a table of the rules and their
+// precision, recall and F1 before and after.
+func WriteRules(w io.Writer, rules []RuleSuggestion) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "from\tto\tsupport\tprecision\trecall\tF1")
+	for _, r := range rules {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.3f → %.3f\t%.3f → %.3f\t%.3f → %.3f\n",
+			r.From, r.To, r.Support,
+			r.Before.Precision(), r.After.Precision(),
+			r.Before.Recall(), r.After.Recall(),
+			r.Before.F1(), r.After.F1())
+	}
+	return tw.Flush()
+}
+
+// segments aligns a and b and returns the differing segments of the
+// alignment as pairs of the text of a and of b, ordered so that the
+// first is less than the second. A segment that is empty on one side
+// (a dropped virama) is widened by a neighbouring letter, so that it
+// makes a rule that doesn't apply everywhere, and one that can't be
+// widened is dropped.
+func segments(a, b []rune) [][2]string {
+	var (
+		edits = align(a, b)
+		out   [][2]string
+	)
+	for i := 0; i < len(edits); {
+		if edits[i].a == edits[i].b {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(edits) && edits[j].a != edits[j].b {
+			j++
+		}
+		start, end := i, j
+		if segmentSide(edits[start:end], true) == "" || segmentSide(edits[start:end], false) == "" {
+			switch {
+			case end < len(edits):
+				end++
+			case start > 0:
+				start--
+			}
+		}
+
+		x, y := segmentSide(edits[start:end], true), segmentSide(edits[start:end], false)
+		if y < x {
+			x, y = y, x
+		}
+		// A segment that is still empty on one side, in a word with no
+		// letter to anchor it to, would make a rule that inserts letters
+		// everywhere.
+		if x != "" {
+			out = append(out, [2]string{x, y})
+		}
+		i = j
+	}
+	return out
+}
+
+// segmentSide returns the text of a's side of edits, or of b's.
+func segmentSide(edits []edit, a bool) string {
+	var out []rune
+	for _, e := range edits {
+		r := e.b
+		if a {
+			r = e.a
+		}
+		if r != 0 {
+			out = append(out, r)
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,82 @@
+package eval
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+func TestSuggestRules(t *testing.T) {
+	pairs := []Pair{
+		{"பழம்", "பளம்", true},
+		{"மழை", "மளை", true},
+		{"வாழை", "வாளை", true},
+		{"கடல்", "கடள்", true},
+		{"முருகன்", "முருகன்", true},
+		{"கடல்", "மரம்", false},
+		{"மழை", "மலை", false},
+	}
+
+	rules := SuggestRules(nil, pairs, taphone.Key0, 0)
+	if len(rules) == 0 {
+		t.Fatal("SuggestRules() = none, want a rule for ழ and ள")
+	}
+	r := rules[0]
+	if got := [2]string{r.From, r.To}; got != [2]string{"ள", "ழ"} && got != [2]string{"ழ", "ள"} {
+		t.Errorf("first rule = %s → %s, want ழ and ள", r.From, r.To)
+	}
+	// The rule finds the missed matches but merges மழை and மலை.
+	if r.Support != 3 || r.After.F1() <= r.Before.F1() || r.After.FN != 0 || r.After.FP != 1 {
+		t.Errorf("first rule = %+v, want support 3, no false negatives and one false positive", r)
+	}
+	for i := 1; i < len(rules); i++ {
+		if rules[i].After.F1() > rules[i-1].After.F1() {
+			t.Errorf("rules out of order: %+v", rules)
+		}
+	}
+
+	if got := SuggestRules(nil, pairs, taphone.Key0, 1); len(got) != 1 {
+		t.Errorf("SuggestRules(n = 1) = %d rules, want 1", len(got))
+	}
+
+	// The rule applied as a profile leaves nothing to suggest for it.
+	opts := []taphone.Option{taphone.WithProfile(r.Profile())}
+	for _, s := range SuggestRules(opts, pairs, taphone.Key0, 0) {
+		if s.From == r.From || s.From == r.To {
+			t.Errorf("SuggestRules() with the rule applied = %+v", s)
+		}
+	}
+}
+
+func TestSegments(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want [][2]string
+	}{
+		{"பழம்", "பளம்", [][2]string{{"ள", "ழ"}}},
+		{"முருகன்", "முருகன்", nil},
+		{"கணேசன்", "கனேஷன்", [][2]string{{"ண", "ன"}, {"ச", "ஷ"}}},
+		{"படம்", "பட்டம்", [][2]string{{"ட", "ட்ட"}}},
+		{"ஆ", "", nil},
+	}
+	for _, c := range cases {
+		if got := segments([]rune(c.a), []rune(c.b)); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("segments(%q, %q) = %q, want %q", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestWriteRules(t *testing.T) {
+	var b bytes.Buffer
+	r := RuleSuggestion{From: "ழ", To: "ள", Support: 3, Before: Counts{TP: 1, FN: 3}, After: Counts{TP: 4}}
+	if err := WriteRules(&b, []RuleSuggestion{r}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], "ழ") || !strings.Contains(lines[1], "0.250 → 1.000") {
+		t.Errorf("WriteRules() =\n%s", b.String())
+	}
+}
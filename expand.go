@@ -0,0 +1,90 @@
+package taphone
+
+import (
+	"regexp"
+	"strings"
+)
+
+// defaultExpansions maps common abbreviations of titles and honorifics to
+// their expanded forms.
+var defaultExpansions = map[string]string{
+	"டா.":      "டாக்டர்",
+	"டாக்.":    "டாக்டர்",
+	"Dr.":      "டாக்டர்",
+	"திரு.":    "திரு",
+	"திருமதி.": "திருமதி",
+	"செல்வி.":  "செல்வி",
+	"பேரா.":    "பேராசிரியர்",
+	"மரு.":     "மருத்துவர்",
+	"பொறி.":    "பொறியாளர்",
+	"வழக்.":    "வழக்கறிஞர்",
+}
+
+// regexInitials matches two consecutive initials separated by whitespace
+// (மு. க.) to canonicalise them to their unspaced form (மு.க.).
+var regexInitials = regexp.MustCompile(`(^|\s)((?:[அ-ஹ][\x{0BBE}-\x{0BCD}\x{0BD7}]?\.)+)\s+([அ-ஹ][\x{0BBE}-\x{0BCD}\x{0BD7}]?\.)`)
+
+// DefaultExpansions returns a copy of the default abbreviation and
+// honorific expansion table.
+func DefaultExpansions() map[string]string {
+	return copyMap(defaultExpansions)
+}
+
+// WithExpansions expands abbreviations in the input before it is encoded,
+// so that abbreviated and expanded forms of names key compatibly. The map
+// holds abbreviations, with their full stops, and their expansions. Keys
+// with initials should be written without spaces (மு.க.); spaced initials
+// in the input (மு. க.) are canonicalised before lookup. Use
+// DefaultExpansions for a table of common titles and honorifics.
+func WithExpansions(m map[string]string) Option {
+	exp := copyMap(m)
+
+	return func(k *TAphone) {
+		k.addFilter("expansions", func(s string) string {
+			return expand(s, exp)
+		})
+		if k.expansions == nil {
+			k.expansions = make(map[string]string)
+		}
+		for a, e := range exp {
+			k.expansions[a] = e
+		}
+	}
+}
+
+// expand replaces whitespace separated words in s that are in the
+// expansion table. Words are expanded before and after spaced initials
+// are canonicalised, so that abbreviations that look like initials (டா.)
+// aren't merged into them.
+func expand(s string, exp map[string]string) string {
+	s = expandWords(s, exp)
+	for {
+		c := regexInitials.ReplaceAllString(s, "$1$2$3")
+		if c == s {
+			break
+		}
+		s = c
+	}
+	return expandWords(s, exp)
+}
+
+func expandWords(s string, exp map[string]string) string {
+	f := strings.Fields(s)
+	changed := false
+	for i, w := range f {
+		if e, ok := exp[w]; ok {
+			f[i], changed = e, true
+			continue
+		}
+
+		// Abbreviations followed by trailing punctuation.
+		t := strings.TrimRight(w, ",;:")
+		if e, ok := exp[t]; ok && t != w {
+			f[i], changed = e+w[len(t):], true
+		}
+	}
+	if !changed {
+		return s
+	}
+	return strings.Join(f, " ")
+}
@@ -0,0 +1,50 @@
+package taphone
+
+import "testing"
+
+func TestExpand(t *testing.T) {
+	exp := DefaultExpansions()
+	exp["மு.க."] = "முத்துவேல் கருணாநிதி"
+
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"title", "டா. ராமன்", "டாக்டர் ராமன்"},
+		{"latin title", "Dr. ராமன்", "டாக்டர் ராமன்"},
+		{"honorific without stop", "திரு. முருகன்", "திரு முருகன்"},
+		{"trailing punctuation", "பேரா., மரு.", "பேராசிரியர், மருத்துவர்"},
+		{"unspaced initials", "மு.க. ஸ்டாலின்", "முத்துவேல் கருணாநிதி ஸ்டாலின்"},
+		{"spaced initials", "மு. க. ஸ்டாலின்", "முத்துவேல் கருணாநிதி ஸ்டாலின்"},
+		{"title before initials", "டா. மு. க. ஸ்டாலின்", "டாக்டர் முத்துவேல் கருணாநிதி ஸ்டாலின்"},
+		{"unknown initials", "கா. மு. ஷெரீப்", "கா.மு. ஷெரீப்"},
+		{"no abbreviations", "வணக்கம் நண்பர்களே", "வணக்கம் நண்பர்களே"},
+		{"abbreviation inside a word", "டா.க்டர்", "டா.க்டர்"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := expand(c.input, exp); got != c.want {
+				t.Errorf("expand(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithExpansions(t *testing.T) {
+	k := New(WithExpansions(DefaultExpansions()))
+	if got, want := k.EncodeLevel("டா. ராமன்", Key2), New().EncodeLevel("டாக்டர் ராமன்", Key2); got != want {
+		t.Errorf("EncodeLevel(டா. ராமன்) = %q, want %q", got, want)
+	}
+
+	// The table is copied.
+	m := map[string]string{"பேரா.": "பேராசிரியர்"}
+	k = New(WithExpansions(m))
+	m["பேரா."] = "x"
+	if got := k.Rules().Expansions["பேரா."]; got != "பேராசிரியர்" {
+		t.Errorf("Rules().Expansions[பேரா.] = %q after changing the map", got)
+	}
+	if DefaultExpansions()["டா."] != "டாக்டர்" {
+		t.Error("DefaultExpansions() lost டா.")
+	}
+}
@@ -0,0 +1,104 @@
+package taphone
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// FingerprintShingleSize is the number of consecutive words in each
+// shingle of a Fingerprint.
+const FingerprintShingleSize = 3
+
+// Fingerprint is a phonetic fingerprint of a document: the sorted set of
+// hashes of its shingles, runs of consecutive word keys. Documents that
+// share most of their shingles are near-duplicates.
+type Fingerprint []uint64
+
+// Fingerprint returns the phonetic fingerprint of a Tamil text. Words are
+// keyed at key0 after dropping stopwords and non-Tamil tokens, so that
+// fingerprints are tolerant to spelling variation as well as to changes
+// in punctuation, numbers and embedded Latin text. A text shorter than a
+// shingle has a single shingle of all its words.
+func (k *TAphone) Fingerprint(text string) Fingerprint {
+	shingles := k.Shingles(text, FingerprintShingleSize)
+	if len(shingles) == 0 {
+		return nil
+	}
+
+	seen := make(map[uint64]bool)
+	out := make(Fingerprint, 0, len(shingles))
+	for _, s := range shingles {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		if s := h.Sum64(); !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// Shingles returns the phonetic shingles of a Tamil text: the key0 keys
+// of each run of n consecutive words, joined by the phrase separator
+// (see WithPhraseSeparator). Stopwords and non-Tamil tokens are dropped
+// as by EncodePhrase. A text of fewer than n words has a single shingle
+// of all its words.
+func (k *TAphone) Shingles(text string, n int) []string {
+	words := k.phraseKeys(text)[Key0]
+	if len(words) == 0 {
+		return nil
+	}
+	if n < 1 {
+		n = 1
+	}
+	if len(words) < n {
+		n = len(words)
+	}
+
+	out := make([]string, 0, len(words)-n+1)
+	for i := 0; i+n <= len(words); i++ {
+		out = append(out, strings.Join(words[i:i+n], k.phraseSep))
+	}
+	return out
+}
+
+// Similarity returns the resemblance of two fingerprints, the Jaccard
+// similarity of their shingle sets, between 0 and 1. Near-duplicate
+// documents typically score above 0.5.
+func (f Fingerprint) Similarity(g Fingerprint) float64 {
+	if len(f) == 0 && len(g) == 0 {
+		return 1
+	}
+	n := f.common(g)
+	return float64(n) / float64(len(f)+len(g)-n)
+}
+
+// Containment returns the share of f's shingles that are also in g,
+// between 0 and 1. It is high if the document of f is largely copied
+// from that of g, even if g is much longer.
+func (f Fingerprint) Containment(g Fingerprint) float64 {
+	if len(f) == 0 {
+		return 0
+	}
+	return float64(f.common(g)) / float64(len(f))
+}
+
+// common returns the number of shingles shared by f and g.
+func (f Fingerprint) common(g Fingerprint) int {
+	n := 0
+	for i, j := 0, 0; i < len(f) && j < len(g); {
+		switch {
+		case f[i] < g[j]:
+			i++
+		case f[i] > g[j]:
+			j++
+		default:
+			n++
+			i++
+			j++
+		}
+	}
+	return n
+}
@@ -0,0 +1,79 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	const doc = "சென்னையில் நேற்று பெரிய மழை பெய்தது. பல சாலைகளில் வெள்ளம் தேங்கியது."
+
+	k := New()
+	cases := []struct {
+		name        string
+		a, b        string
+		minSim      float64
+		maxSim      float64
+		containment float64
+	}{
+		{"same text", doc, doc, 1, 1, 1},
+		{"punctuation and numbers", doc, "சென்னையில் நேற்று 12 பெரிய மழை பெய்தது; பல சாலைகளில் வெள்ளம் தேங்கியது!", 1, 1, 1},
+		{"spelling variants", doc, "சென்னையில் நேற்று பெறிய மழை பெய்தது. பல சாலைகளில் வெல்லம் தேங்கியது.", 1, 1, 1},
+		{"unrelated", doc, "இன்று காலை பள்ளிக்கூடம் திறக்கப்பட்டது மாணவர்கள் மகிழ்ச்சியுடன் வந்தனர்", 0, 0, 0},
+		{"empty", "", "", 1, 1, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f, g := k.Fingerprint(c.a), k.Fingerprint(c.b)
+			if s := f.Similarity(g); s < c.minSim || s > c.maxSim {
+				t.Errorf("Similarity() = %v, want %v to %v", s, c.minSim, c.maxSim)
+			}
+			if s := f.Containment(g); s != c.containment {
+				t.Errorf("Containment() = %v, want %v", s, c.containment)
+			}
+		})
+	}
+}
+
+func TestContainment(t *testing.T) {
+	const part = "நேற்று பெரிய மழை பெய்தது பல சாலைகளில்"
+
+	k := New()
+	f := k.Fingerprint(part)
+	g := k.Fingerprint("சென்னையில் " + part + " வெள்ளம் தேங்கியது")
+	if c := f.Containment(g); c != 1 {
+		t.Errorf("Containment() = %v, want 1", c)
+	}
+	if s := f.Similarity(g); s <= 0 || s >= 1 {
+		t.Errorf("Similarity() = %v, want between 0 and 1", s)
+	}
+	if c := g.Containment(f); c <= 0 || c >= 1 {
+		t.Errorf("reverse Containment() = %v, want between 0 and 1", c)
+	}
+}
+
+func TestShingles(t *testing.T) {
+	const text = "வணக்கம் நண்பர்கள், இந்த மரம் பெரியது"
+	cases := []struct {
+		name string
+		opts []Option
+		n    int
+		want []string
+	}{
+		{"pairs", nil, 2, []string{"VNKKM NNPRKL", "NNPRKL MRM", "MRM PR3YT"}},
+		{"single words", nil, 1, []string{"VNKKM", "NNPRKL", "MRM", "PR3YT"}},
+		{"n below one", nil, 0, []string{"VNKKM", "NNPRKL", "MRM", "PR3YT"}},
+		{"longer than the text", nil, 5, []string{"VNKKM NNPRKL MRM PR3YT"}},
+		{"separator", []Option{WithPhraseSeparator(SeparatorCode)}, 3, []string{"VNKKM_NNPRKL_MRM", "NNPRKL_MRM_PR3YT"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := New(c.opts...).Shingles(text, c.n); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Shingles(%d) = %q, want %q", c.n, got, c.want)
+			}
+		})
+	}
+	if got := New().Shingles("hello", 2); got != nil {
+		t.Errorf("Shingles(hello) = %q, want none", got)
+	}
+}
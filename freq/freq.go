@@ -0,0 +1,225 @@
+// Package freq builds word frequency models from Tamil text corpora for
+// use by taphone's dictionary based suggestion and ranking. Models are
+// stored in a compact binary format: words sorted and front coded, with
+// varint counts.
+package freq
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cmrajan/taphone"
+)
+
+// magic identifies the binary model format.
+var magic = [4]byte{'T', 'F', 'Q', '1'}
+
+// Entry is a word and its count.
+type Entry struct {
+	Word  string
+	Count int
+}
+
+// Model is a word frequency model.
+type Model struct {
+	counts map[string]int
+	total  int
+}
+
+// New returns an empty model.
+func New() *Model {
+	return &Model{counts: make(map[string]int)}
+}
+
+// Build builds a model from the Tamil words of one or more corpus files.
+// Everything but Tamil words, including digits, punctuation and markup,
+// is ignored. Each reader is scanned separately, so that a word at the
+// end of one doesn't run into a word at the start of the next.
+func Build(rs ...io.Reader) (*Model, error) {
+	m := New()
+	for _, r := range rs {
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		sc.Split(taphone.ScanTamilWords)
+		for sc.Scan() {
+			m.Add(sc.Text(), 1)
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Add adds n occurrences of a word to the model.
+func (m *Model) Add(word string, n int) {
+	if word == "" || n <= 0 {
+		return
+	}
+	m.counts[word] += n
+	m.total += n
+}
+
+// Count returns the number of occurrences of a word.
+func (m *Model) Count(word string) int {
+	return m.counts[word]
+}
+
+// Len returns the number of distinct words in the model.
+func (m *Model) Len() int {
+	return len(m.counts)
+}
+
+// Total returns the total number of word occurrences in the model.
+func (m *Model) Total() int {
+	return m.total
+}
+
+// Prune removes words that occur fewer than min times, typically typos
+// and noise in large corpora.
+func (m *Model) Prune(min int) {
+	for w, n := range m.counts {
+		if n < min {
+			delete(m.counts, w)
+			m.total -= n
+		}
+	}
+}
+
+// Top returns the n most frequent words, most frequent first. n < 1
+// returns all words.
+func (m *Model) Top(n int) []Entry {
+	out := make([]Entry, 0, len(m.counts))
+	for w, c := range m.counts {
+		out = append(out, Entry{Word: w, Count: c})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Word < out[j].Word
+	})
+	if n > 0 && n < len(out) {
+		out = out[:n]
+	}
+	return out
+}
+
+// Fill adds the words of the model with their counts to a dictionary.
+func (m *Model) Fill(d *taphone.Dictionary) {
+	for w, n := range m.counts {
+		d.Add(w, n)
+	}
+}
+
+// WriteTo writes the model to w in the binary format.
+func (m *Model) WriteTo(w io.Writer) (int64, error) {
+	words := make([]string, 0, len(m.counts))
+	for word := range m.counts {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	var (
+		bw  = bufio.NewWriter(w)
+		n   int64
+		buf [binary.MaxVarintLen64]byte
+	)
+	put := func(b []byte) error {
+		c, err := bw.Write(b)
+		n += int64(c)
+		return err
+	}
+	putUvarint := func(v uint64) error {
+		return put(buf[:binary.PutUvarint(buf[:], v)])
+	}
+
+	if err := put(magic[:]); err != nil {
+		return n, err
+	}
+	if err := putUvarint(uint64(len(words))); err != nil {
+		return n, err
+	}
+
+	// Each word is stored as the length of the prefix it shares with the
+	// previous word, the rest of the word and its count.
+	prev := ""
+	for _, word := range words {
+		p := commonPrefix(prev, word)
+		if err := putUvarint(uint64(p)); err != nil {
+			return n, err
+		}
+		if err := putUvarint(uint64(len(word) - p)); err != nil {
+			return n, err
+		}
+		if err := put([]byte(word[p:])); err != nil {
+			return n, err
+		}
+		if err := putUvarint(uint64(m.counts[word])); err != nil {
+			return n, err
+		}
+		prev = word
+	}
+	return n, bw.Flush()
+}
+
+// Read reads a model written by WriteTo.
+func Read(r io.Reader) (*Model, error) {
+	br := bufio.NewReader(r)
+
+	var mg [4]byte
+	if _, err := io.ReadFull(br, mg[:]); err != nil {
+		return nil, err
+	}
+	if mg != magic {
+		return nil, errors.New("freq: not a frequency model")
+	}
+
+	count, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		m    = New()
+		prev []byte
+	)
+	for i := uint64(0); i < count; i++ {
+		p, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		l, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		if p > uint64(len(prev)) || l > 1<<16 {
+			return nil, fmt.Errorf("freq: corrupt entry %d", i)
+		}
+
+		word := make([]byte, int(p)+int(l))
+		copy(word, prev[:p])
+		if _, err := io.ReadFull(br, word[p:]); err != nil {
+			return nil, err
+		}
+		c, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		m.Add(string(word), int(c))
+		prev = word
+	}
+	return m, nil
+}
+
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
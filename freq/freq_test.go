@@ -0,0 +1,92 @@
+package freq
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name   string
+		inputs []string
+		want   map[string]int
+	}{
+		{"words and noise", []string{"வணக்கம், <b>தமிழ்</b> 2024 hello வணக்கம்"},
+			map[string]int{"வணக்கம்": 2, "தமிழ்": 1}},
+		{"files without a final newline", []string{"கடல்", "மழை\nகடல்"},
+			map[string]int{"கடல்": 2, "மழை": 1}},
+		{"no input", nil, map[string]int{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var rs []io.Reader
+			for _, in := range c.inputs {
+				rs = append(rs, strings.NewReader(in))
+			}
+			m, err := Build(rs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(m.counts, c.want) {
+				t.Errorf("Build() = %v, want %v", m.counts, c.want)
+			}
+		})
+	}
+}
+
+func TestModel(t *testing.T) {
+	m := New()
+	m.Add("கடல்", 3)
+	m.Add("மழை", 5)
+	m.Add("மரம்", 3)
+	m.Add("பந்து", 1)
+	m.Add("", 4)
+	m.Add("அம்மா", 0)
+
+	if m.Len() != 4 || m.Total() != 12 {
+		t.Errorf("Len, Total = %d, %d, want 4, 12", m.Len(), m.Total())
+	}
+	want := []Entry{{"மழை", 5}, {"கடல்", 3}, {"மரம்", 3}}
+	if got := m.Top(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("Top(3) = %v, want %v", got, want)
+	}
+
+	m.Prune(2)
+	if m.Count("பந்து") != 0 || m.Len() != 3 || m.Total() != 11 {
+		t.Errorf("after Prune(2): Count, Len, Total = %d, %d, %d, want 0, 3, 11",
+			m.Count("பந்து"), m.Len(), m.Total())
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	m := New()
+	for w, n := range map[string]int{"கடல்": 3, "கடவுள்": 7, "மழை": 1, "மரம்": 2} {
+		m.Add(w, n)
+	}
+
+	var buf bytes.Buffer
+	n, err := m.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo() = %d, wrote %d bytes", n, buf.Len())
+	}
+
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.counts, m.counts) || got.total != m.total {
+		t.Errorf("Read() = %v (%d), want %v (%d)", got.counts, got.total, m.counts, m.total)
+	}
+
+	for _, in := range []string{"", "TFQ", "XXXX\x00", "TFQ1\x01\x05"} {
+		if _, err := Read(strings.NewReader(in)); err == nil {
+			t.Errorf("Read(%q) succeeded, want an error", in)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+//go:build go1.18
+// +build go1.18
+
+package taphone
+
+import "testing"
+
+// FuzzEncode checks that keys of arbitrary input are well formed. Its
+// seed corpus in testdata/fuzz/FuzzEncode is collected from real text
+// with taphone seeds.
+func FuzzEncode(f *testing.F) {
+	f.Add("தமிழ்")
+	k := New()
+	f.Fuzz(func(t *testing.T, s string) {
+		k0, k1, k2 := k.Encode(s)
+		if regexAlphaNum.MatchString(k0 + k1 + k2) {
+			t.Fatalf("Encode(%q) = %q %q %q, want codes only", s, k0, k1, k2)
+		}
+		if k1 != regexKey1.ReplaceAllString(k2, "") || k0 != regexKey0.ReplaceAllString(k2, "") {
+			t.Fatalf("Encode(%q) = %q %q %q, key0 and key1 aren't derived from key2", s, k0, k1, k2)
+		}
+	})
+}
+
+// FuzzEncodeRunes checks that EncodeRunes, which encodes runes directly,
+// agrees with EncodeKeys.
+func FuzzEncodeRunes(f *testing.F) {
+	f.Add("ஃபேன் ஜெயா")
+	encs := []*TAphone{New(), New(WithLoanSounds()), New(WithLoanSounds(), WithLoanFolding())}
+	f.Fuzz(func(t *testing.T, s string) {
+		r := []rune(s)
+		for _, k := range encs {
+			if got, want := k.EncodeRunes(r), k.EncodeKeys(string(r)); got != want {
+				t.Fatalf("EncodeRunes(%q) = %+v, want %+v", s, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,204 @@
+// Package gazetteer resolves misspelled Tamil place names to canonical
+// entries of a place-name gazetteer (district, taluk and village lists)
+// using taphone phonetic similarity, constrained by the administrative
+// hierarchy. It is intended for address cleaning pipelines.
+package gazetteer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cmrajan/taphone"
+)
+
+// DefaultMinScore is the default minimum phonetic similarity for a name
+// to resolve to a gazetteer entry.
+const DefaultMinScore = 0.7
+
+// Address is a place in the district > taluk > village hierarchy. Any of
+// its parts may be empty.
+type Address struct {
+	District string
+	Taluk    string
+	Village  string
+}
+
+// place is a node in the gazetteer hierarchy.
+type place struct {
+	name     string
+	parent   *place
+	children map[string]*place
+}
+
+func newPlace(name string, parent *place) *place {
+	return &place{name: name, parent: parent, children: make(map[string]*place)}
+}
+
+// path returns the names of the place and its ancestors, from the top
+// of the hierarchy down.
+func (p *place) path() []string {
+	var out []string
+	for ; p.parent != nil; p = p.parent {
+		out = append([]string{p.name}, out...)
+	}
+	return out
+}
+
+// pathLess reports whether path a sorts before path b, comparing names
+// from the top of the hierarchy down.
+func pathLess(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// Gazetteer is a hierarchy of canonical place names.
+type Gazetteer struct {
+	enc  *taphone.TAphone
+	root *place
+
+	// MinScore is the minimum similarity for a name to resolve.
+	MinScore float64
+}
+
+// New returns an empty gazetteer that compares names with the given
+// encoder.
+func New(enc *taphone.TAphone) *Gazetteer {
+	return &Gazetteer{enc: enc, root: newPlace("", nil), MinScore: DefaultMinScore}
+}
+
+// Load reads a gazetteer from CSV. The first row is a header that must
+// have a district column and may have taluk and village columns (case
+// insensitive); other columns are ignored. Each row adds a place and its
+// ancestors.
+func Load(enc *taphone.TAphone, r io.Reader) (*Gazetteer, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	hdr, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading header: %v", err)
+	}
+	cols := map[string]int{"district": -1, "taluk": -1, "village": -1}
+	for i, h := range hdr {
+		if _, ok := cols[strings.ToLower(strings.TrimSpace(h))]; ok {
+			cols[strings.ToLower(strings.TrimSpace(h))] = i
+		}
+	}
+	if cols["district"] < 0 {
+		return nil, errors.New("header has no district column")
+	}
+
+	g := New(enc)
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		get := func(c string) string {
+			if i := cols[c]; i > -1 && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		g.Add(Address{District: get("district"), Taluk: get("taluk"), Village: get("village")})
+	}
+	return g, nil
+}
+
+// Add adds a place to the gazetteer. Parts of the address after the
+// first empty part are ignored.
+func (g *Gazetteer) Add(a Address) {
+	p := g.root
+	for _, name := range []string{a.District, a.Taluk, a.Village} {
+		if name == "" {
+			return
+		}
+		c, ok := p.children[name]
+		if !ok {
+			c = newPlace(name, p)
+			p.children[name] = c
+		}
+		p = c
+	}
+}
+
+// Resolve resolves a possibly misspelled address to the canonical
+// gazetteer address that sounds most like it. Each given part of the
+// address is matched among the children of the places its parent part
+// could resolve to, or among all places of its level if the parent is
+// unknown. The score is the mean similarity of the given parts. It
+// returns false if any given part doesn't resolve with at least MinScore.
+func (g *Gazetteer) Resolve(a Address) (Address, float64, bool) {
+	type cand struct {
+		p     *place
+		score float64
+		n     int
+	}
+
+	// Only descend as far as the last given part.
+	parts := []string{a.District, a.Taluk, a.Village}
+	for len(parts) > 0 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+
+	cands := []cand{{p: g.root}}
+	for _, name := range parts {
+		var next []cand
+		for _, c := range cands {
+			for _, ch := range c.p.children {
+				if name == "" {
+					next = append(next, cand{p: ch, score: c.score, n: c.n})
+					continue
+				}
+				if s := g.enc.NameSimilarity(name, ch.name); s >= g.MinScore {
+					next = append(next, cand{p: ch, score: c.score + s, n: c.n + 1})
+				}
+			}
+		}
+		if len(next) == 0 {
+			return Address{}, 0, false
+		}
+		cands = next
+	}
+
+	var best *cand
+	for i := range cands {
+		c := &cands[i]
+		if c.n == 0 {
+			continue
+		}
+		// Break ties by the full path, as the same name can occur under
+		// different parents.
+		if best == nil || c.score/float64(c.n) > best.score/float64(best.n) ||
+			(c.score/float64(c.n) == best.score/float64(best.n) && pathLess(c.p.path(), best.p.path())) {
+			best = c
+		}
+	}
+	if best == nil {
+		return Address{}, 0, false
+	}
+
+	var out Address
+	for i, n := range best.p.path() {
+		switch i {
+		case 0:
+			out.District = n
+		case 1:
+			out.Taluk = n
+		case 2:
+			out.Village = n
+		}
+	}
+	return out, best.score / float64(best.n), true
+}
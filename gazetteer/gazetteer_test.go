@@ -0,0 +1,88 @@
+package gazetteer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+const testCSV = `District,Taluk,Village,Code
+மதுரை,மேலூர்,கொட்டாம்பட்டி,1
+மதுரை,உசிலம்பட்டி,செல்லம்பட்டி,2
+மதுரை,மேலூர்,புதூர்,3
+சேலம்,ஆத்தூர்,புதூர்,4
+திருநெல்வேலி,,,5
+`
+
+func TestResolve(t *testing.T) {
+	g, err := Load(taphone.New(), strings.NewReader(testCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name string
+		in   Address
+		want Address
+		ok   bool
+	}{
+		{"exact", Address{"மதுரை", "மேலூர்", "கொட்டாம்பட்டி"},
+			Address{"மதுரை", "மேலூர்", "கொட்டாம்பட்டி"}, true},
+		{"misspelt village", Address{"மதுரை", "", "கொட்டம்பட்டி"},
+			Address{"மதுரை", "மேலூர்", "கொட்டாம்பட்டி"}, true},
+		{"misspelt district", Address{District: "மதுறை"}, Address{District: "மதுரை"}, true},
+		{"village under its district", Address{"சேலம்", "", "புதூர்"},
+			Address{"சேலம்", "ஆத்தூர்", "புதூர்"}, true},
+		{"same village name breaks ties by path", Address{Village: "புதூர்"},
+			Address{"சேலம்", "ஆத்தூர்", "புதூர்"}, true},
+		{"district without taluks", Address{District: "திருநெல்வேலி"},
+			Address{District: "திருநெல்வேலி"}, true},
+		{"unknown village", Address{"மதுரை", "", "வணக்கம்"}, Address{}, false},
+		{"empty address", Address{}, Address{}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, score, ok := g.Resolve(c.in)
+			if got != c.want || ok != c.ok {
+				t.Errorf("Resolve(%v) = %v, %v, want %v, %v", c.in, got, ok, c.want, c.ok)
+			}
+			if ok && (score < g.MinScore || score > 1) {
+				t.Errorf("Resolve(%v) score = %v, want in [%v, 1]", c.in, score, g.MinScore)
+			}
+		})
+	}
+}
+
+func TestResolveStable(t *testing.T) {
+	g, err := Load(taphone.New(), strings.NewReader(testCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _, _ := g.Resolve(Address{Village: "புதூர்"})
+	for i := 0; i < 50; i++ {
+		if got, _, _ := g.Resolve(Address{Village: "புதூர்"}); got != first {
+			t.Fatalf("Resolve() = %v, then %v", first, got)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		ok    bool
+	}{
+		{"header case and spacing", " DISTRICT , Village\nமதுரை,புதூர்\n", true},
+		{"no district column", "Taluk,Village\nமேலூர்,புதூர்\n", false},
+		{"empty", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Load(taphone.New(), strings.NewReader(c.input))
+			if (err == nil) != c.ok {
+				t.Errorf("Load(%q) error = %v, want ok %v", c.input, err, c.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,428 @@
+package taphone
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// keyToken is a single code in a key with the modifier digits that
+// follow it.
+type keyToken struct {
+	code string
+	mods string
+}
+
+// GenerateSpellings returns up to limit plausible Tamil spellings that
+// encode to key, by inverting the encoding tables. The key may be of
+// any level; digits present in it narrow the level it is matched at.
+// Every spelling returned is verified to encode back to the key.
+// Spellings are ordered by plausibility. Letters are tried in the order
+// of how often they occur in Tamil text, the virama is tried first at the
+// end of a word and in likely clusters (geminates such as க்க and nasals
+// before stops such as ந்த), the inherent vowel first elsewhere, and a
+// word final stop is tried with the u sign first when the key doesn't
+// tell (பந்து). Spellings are ranked by the likelihood of their letter
+// sequences in the default wordlist, with ties in that order. A limit
+// <= 0 returns nothing.
+func (k *TAphone) GenerateSpellings(key string, limit int) []string {
+	if limit <= 0 {
+		return nil
+	}
+	toks, ok := tokenizeKey(key)
+	if !ok || len(toks) == 0 {
+		return nil
+	}
+
+	var (
+		levels = matchLevels(key)
+		loose  = levels[Key0] || levels[Key1]
+		virama = viramaPositions(toks, loose)
+		opts   = make([][]string, len(toks))
+	)
+	for i, t := range toks {
+		opts[i] = spellingOptions(t, loose, virama[i], i == len(toks)-1)
+		if len(opts[i]) == 0 {
+			return nil
+		}
+	}
+
+	// Search the spellings a token at a time, keeping the most likely
+	// prefixes that still encode to a prefix of the key.
+	width := spellingBeam
+	if limit > width {
+		width = limit
+	}
+	beam := []spelling{{}}
+	for i := range toks {
+		var (
+			next []spelling
+			seen = make(map[string]bool)
+		)
+		for _, b := range beam {
+			for _, o := range opts[i] {
+				c := b.extend(o)
+				if seen[c.text] {
+					continue
+				}
+				seen[c.text] = true
+
+				keys := k.keys(c.text)
+				if i == len(toks)-1 {
+					if !keyMatches(keys, key, levels, false) {
+						continue
+					}
+					c = c.extend("")
+				} else if !keyMatches(keys, key, levels, true) {
+					continue
+				}
+				next = append(next, c)
+			}
+		}
+
+		// Options are in order of preference, which breaks ties.
+		sort.SliceStable(next, func(i, j int) bool { return next[i].score > next[j].score })
+		if len(next) > width {
+			next = next[:width]
+		}
+		beam = next
+	}
+
+	if len(beam) > limit {
+		beam = beam[:limit]
+	}
+	out := make([]string, len(beam))
+	for i, b := range beam {
+		out[i] = b.text
+	}
+	return out
+}
+
+// GenerateSpellings returns up to limit dictionary words that encode to
+// key at the levels it can be matched at, in descending order of
+// frequency.
+func (d *Dictionary) GenerateSpellings(key string, limit int) []string {
+	levels := matchLevels(key)
+
+	d.mu.RLock()
+	var (
+		entries []*entry
+		seen    = make(map[*entry]bool)
+	)
+	for l := Key0; l <= Key2; l++ {
+		if !levels[l] {
+			continue
+		}
+		for _, e := range d.index[l][key] {
+			if !seen[e] {
+				seen[e] = true
+				entries = append(entries, e)
+			}
+		}
+	}
+	d.mu.RUnlock()
+
+	sortEntries(entries)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.word
+	}
+	return out
+}
+
+// matchLevels returns the key levels a key can belong to, judging by the
+// digits it contains.
+func matchLevels(key string) [3]bool {
+	return [3]bool{
+		Key0: !regexKey0.MatchString(key),
+		Key1: !regexKey1.MatchString(key),
+		Key2: true,
+	}
+}
+
+// keyMatches reports whether any of keys at the given levels equals key,
+// or is a prefix of it if prefix is set.
+func keyMatches(keys [3]string, key string, levels [3]bool, prefix bool) bool {
+	for l, ok := range levels {
+		if !ok {
+			continue
+		}
+		if keys[l] == key || (prefix && strings.HasPrefix(key, keys[l])) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenizeKey splits a key into codes and their modifier digits, matching
+// the longest code first. It returns false if the key contains anything
+// that isn't a known code.
+func tokenizeKey(key string) ([]keyToken, bool) {
+	var (
+		codes = keyCodeSet
+		out   []keyToken
+	)
+	for i := 0; i < len(key); {
+		c := key[i]
+		if c >= '0' && c <= '9' {
+			if len(out) == 0 {
+				return nil, false
+			}
+			out[len(out)-1].mods += string(c)
+			i++
+			continue
+		}
+
+		n := 0
+		for l := 2; l > 0; l-- {
+			if i+l <= len(key) && codes[key[i:i+l]] {
+				n = l
+				break
+			}
+		}
+		if n == 0 {
+			return nil, false
+		}
+		out = append(out, keyToken{code: key[i : i+n]})
+		i += n
+	}
+	return out, true
+}
+
+// keyCodeSet is the set of codes returned by keyCodes.
+var keyCodeSet = keyCodes()
+
+// keyCodes returns the set of consonant, vowel and loan sound codes.
+func keyCodes() map[string]bool {
+	out := make(map[string]bool)
+	for _, v := range consonants {
+		out[v] = true
+	}
+	for _, v := range vowels {
+		out[v] = true
+	}
+	for _, l := range loanLetters {
+		out[l[1]] = true
+	}
+	return out
+}
+
+// spellingOptions returns the Tamil glyph sequences that can produce a key
+// token, most likely first. If loose is set, the token may come from a
+// broad key that has lost its hard sound and modifier digits. virama
+// tells whether the token is likely a consonant without a vowel, and
+// last whether it ends the word.
+func spellingOptions(t keyToken, loose, virama, last bool) []string {
+	var bases []string
+	for g, v := range vowels {
+		if v == t.code {
+			bases = append(bases, g)
+		}
+	}
+	if len(bases) > 0 {
+		sortByFrequency(bases)
+		return bases
+	}
+
+	for g, v := range consonants {
+		if v == t.code || (loose && v == t.code+"1") {
+			bases = append(bases, g)
+		}
+	}
+	for _, l := range loanLetters {
+		if l[1] == t.code {
+			bases = append(bases, l[0])
+		}
+	}
+	sortByFrequency(bases)
+
+	var signs []string
+	switch {
+	case t.mods != "":
+		for s, v := range modifiers {
+			if v == t.mods {
+				signs = append(signs, s)
+			}
+		}
+		sort.Strings(signs)
+	default:
+		switch {
+		case last && loose && isStopCode(t.code, loose):
+			// Native words don't end in a stop, but in a stop with
+			// the u sign, which broad keys drop.
+			signs = []string{"ு", "்", ""}
+		case virama:
+			signs = []string{"்", "", "ா"}
+		default:
+			signs = []string{"", "்", "ா"}
+		}
+		if loose {
+			var extra []string
+			for s, v := range modifiers {
+				if v != "" && v != "3" && s != signs[0] {
+					extra = append(extra, s)
+				}
+			}
+			sort.Strings(extra)
+			signs = append(signs, extra...)
+		}
+	}
+
+	var out []string
+	for _, b := range bases {
+		for _, s := range signs {
+			out = append(out, b+s)
+		}
+	}
+	return out
+}
+
+// viramaPositions reports for each token whether it is likely a
+// consonant without a vowel: one without modifiers that ends the word,
+// or that starts a geminate (க்க) or a nasal and stop cluster (ந்த),
+// unless the next consonant is itself without a vowel, as native words
+// don't have clusters of three. Words don't start with a cluster.
+func viramaPositions(toks []keyToken, loose bool) []bool {
+	out := make([]bool, len(toks))
+	for i := len(toks) - 1; i > 0; i-- {
+		t := toks[i]
+		if t.mods != "" || isVowelCode(t.code) {
+			continue
+		}
+		if i == len(toks)-1 {
+			// A final stop of a loose key is tried with the u sign.
+			out[i] = !isStopCode(t.code, loose) || !loose
+			continue
+		}
+
+		next := toks[i+1]
+		if out[i+1] || isVowelCode(next.code) {
+			continue
+		}
+		out[i] = baseCode(t.code, loose) == baseCode(next.code, loose) ||
+			(isNasalCode(t.code) && isStopCode(next.code, loose))
+	}
+	return out
+}
+
+// baseCode returns a code without its hard sound suffix if loose is set.
+func baseCode(c string, loose bool) string {
+	if loose {
+		return strings.TrimSuffix(c, "1")
+	}
+	return c
+}
+
+func isVowelCode(c string) bool {
+	for _, v := range vowels {
+		if v == c {
+			return true
+		}
+	}
+	return false
+}
+
+func isNasalCode(c string) bool {
+	switch c {
+	case "NG", "NJ", "N", "N1", "M":
+		return true
+	}
+	return false
+}
+
+// isStopCode reports whether c is the code of a stop. Loose codes may
+// have lost the hard sound suffix that tells ற from ர.
+func isStopCode(c string, loose bool) bool {
+	switch c {
+	case "K", "C", "T", "T1", "P", "R1":
+		return true
+	}
+	return loose && c == "R"
+}
+
+// spellingBeam is the minimum number of spellings that GenerateSpellings
+// keeps at each step of its search.
+const spellingBeam = 64
+
+// spelling is a partial spelling with its log likelihood under a
+// grapheme bigram model of the default wordlist.
+type spelling struct {
+	text  string
+	score float64
+	last  string
+}
+
+// extend returns the spelling followed by the graphemes of s, which is
+// "" at the end of the word.
+func (sp spelling) extend(s string) spelling {
+	letterStatsOnce.Do(initLetterStats)
+	gs := Graphemes(s)
+	if s == "" {
+		gs = []string{""}
+	}
+	n := float64(len(graphemeFreq))
+	for _, g := range gs {
+		sp.score += math.Log((float64(graphemePairs[[2]string{sp.last, g}]) + spellingSmoothing) /
+			(float64(graphemeFreq[sp.last]) + spellingSmoothing*n))
+		sp.last = g
+	}
+	sp.text += s
+	return sp
+}
+
+// spellingSmoothing is the count added to every grapheme pair when
+// ranking spellings.
+const spellingSmoothing = 0.1
+
+var (
+	letterStatsOnce sync.Once
+
+	// letterFreq holds the number of occurrences of each letter in the
+	// words of the default wordlist, and graphemeFreq and graphemePairs
+	// those of each grapheme and each pair of adjacent graphemes. Words
+	// are delimited by the empty grapheme.
+	letterFreq    map[rune]int
+	graphemeFreq  map[string]int
+	graphemePairs map[[2]string]int
+)
+
+func initLetterStats() {
+	letterFreq = make(map[rune]int)
+	graphemeFreq = make(map[string]int)
+	graphemePairs = make(map[[2]string]int)
+	for _, l := range strings.Split(defaultWordlist, "\n") {
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		if i := strings.IndexByte(l, '\t'); i > -1 {
+			l = l[:i]
+		}
+		for _, r := range l {
+			letterFreq[r]++
+		}
+
+		prev := ""
+		for _, g := range append(Graphemes(l), "") {
+			graphemeFreq[prev]++
+			graphemePairs[[2]string{prev, g}]++
+			prev = g
+		}
+	}
+}
+
+// sortByFrequency sorts glyphs by how often their first letter occurs in
+// the default wordlist, most frequent first.
+func sortByFrequency(glyphs []string) {
+	letterStatsOnce.Do(initLetterStats)
+	sort.Slice(glyphs, func(i, j int) bool {
+		a, b := []rune(glyphs[i])[0], []rune(glyphs[j])[0]
+		if letterFreq[a] != letterFreq[b] {
+			return letterFreq[a] > letterFreq[b]
+		}
+		return glyphs[i] < glyphs[j]
+	})
+}
@@ -0,0 +1,56 @@
+package taphone
+
+import "testing"
+
+func TestGenerateSpellings(t *testing.T) {
+	cases := []struct {
+		key  string
+		want string
+	}{
+		{"VNKKM", "வணக்கம்"},
+		{"TM3Z", "தமிழ்"},
+		{"T1M3Z", "தமிழ்"},
+		{"PNT1", "பந்து"},
+		{"PNT14", "பந்து"},
+		{"PNT1YM", "பந்தயம்"},
+		{"MZ6", "மழை"},
+		{"KTL", "கடல்"},
+		{"NNPN1", "நண்பன்"},
+		{"PLL3", "பள்ளி"},
+		{"MRM", "மரம்"},
+		{"KNNN1", "கண்ணன்"},
+		{"T1NGKM", "தங்கம்"},
+		{"C5N1N16", "சென்னை"},
+	}
+
+	k := New()
+	for _, c := range cases {
+		t.Run(c.key, func(t *testing.T) {
+			got := k.GenerateSpellings(c.key, 5)
+			if len(got) == 0 || got[0] != c.want {
+				t.Fatalf("GenerateSpellings(%q) = %q, want %q first", c.key, got, c.want)
+			}
+
+			// Every spelling encodes back to the key at a level it can
+			// belong to.
+			levels := matchLevels(c.key)
+			for _, s := range got {
+				if !keyMatches(k.keys(s), c.key, levels, false) {
+					t.Errorf("%q doesn't encode to %q", s, c.key)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSpellingsInvalid(t *testing.T) {
+	k := New()
+	for _, key := range []string{"", "3M", "QQZ1x", "vnkkm"} {
+		if got := k.GenerateSpellings(key, 5); len(got) != 0 {
+			t.Errorf("GenerateSpellings(%q) = %q, want none", key, got)
+		}
+	}
+	if got := k.GenerateSpellings("VNKKM", 0); got != nil {
+		t.Errorf("GenerateSpellings with limit 0 = %q, want nil", got)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/cmrajan/taphone
 
-go 1.12
+go 1.16
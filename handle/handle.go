@@ -0,0 +1,170 @@
+// Package handle checks proposed usernames and handles for impersonation
+// risks. A handle is reported if it sounds like an existing name, in
+// Tamil or Thanglish, or if it mixes scripts, lookalike characters or
+// invisible characters that make it look like another name.
+package handle
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/cmrajan/taphone"
+)
+
+// DefaultMinScore is the default minimum phonetic similarity at which an
+// existing name is reported as a collision. It is the score of names
+// that share key0.
+const DefaultMinScore = 0.8
+
+// scripts are the scripts told apart when checking for mixed scripts.
+// Letters of other scripts count as one further script.
+var scripts = []*unicode.RangeTable{
+	unicode.Tamil, unicode.Latin, unicode.Devanagari, unicode.Malayalam,
+	unicode.Telugu, unicode.Kannada, unicode.Sinhala, unicode.Cyrillic,
+	unicode.Greek,
+}
+
+// Collision is an existing name that a handle may be confused with.
+type Collision struct {
+	Name  string
+	Score float64
+}
+
+// Report is the result of checking a handle.
+type Report struct {
+	// Collisions are the existing names that sound like the handle, most
+	// similar first.
+	Collisions []Collision
+
+	// MixedScript is set if the handle has letters from more than one
+	// script (முருகan), a common way of spoofing a name.
+	MixedScript bool
+
+	// Invisible is set if the handle has invisible formatting characters
+	// such as zero width joiners or bidi controls.
+	Invisible bool
+
+	// Confusable is set if the handle has characters that look like
+	// Tamil letters, such as Tamil digits or Malayalam letters, inside
+	// Tamil words (௧ண்ணன்).
+	Confusable bool
+}
+
+// OK reports whether the check found no risks.
+func (r Report) OK() bool {
+	return len(r.Collisions) == 0 && !r.MixedScript && !r.Invisible && !r.Confusable
+}
+
+// Checker checks handles against a set of existing names.
+type Checker struct {
+	enc *taphone.TAphone
+
+	mu    sync.RWMutex
+	names map[string]string
+
+	// MinScore is the minimum similarity of reported collisions.
+	MinScore float64
+}
+
+// New returns a checker for the given existing names.
+func New(enc *taphone.TAphone, names []string) *Checker {
+	c := &Checker{
+		enc:      enc,
+		names:    make(map[string]string),
+		MinScore: DefaultMinScore,
+	}
+	c.Add(names...)
+	return c
+}
+
+// Add adds existing names to the checker.
+func (c *Checker) Add(names ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, n := range names {
+		if s := normalize(n); s != "" {
+			c.names[n] = s
+		}
+	}
+}
+
+// Check checks a proposed handle. Lookalike characters are folded to
+// Tamil and digits, punctuation and separators (raja_kumar99) are
+// ignored when comparing names. Tamil and Thanglish handles are compared
+// with each other phonetically.
+func (c *Checker) Check(handle string) Report {
+	r := Report{
+		MixedScript: isMixedScript(handle),
+		Invisible:   hasInvisible(handle),
+		Confusable:  taphone.FoldConfusables(handle) != handle,
+	}
+
+	h := normalize(handle)
+	if h == "" {
+		return r
+	}
+
+	c.mu.RLock()
+	for name, n := range c.names {
+		score := 1.0
+		if h != n {
+			score = c.enc.Similarity(h, n)
+		}
+		if score >= c.MinScore {
+			r.Collisions = append(r.Collisions, Collision{Name: name, Score: score})
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(r.Collisions, func(i, j int) bool {
+		a, b := r.Collisions[i], r.Collisions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Name < b.Name
+	})
+	return r
+}
+
+// normalize reduces a handle to its lowercased letters and marks, with
+// lookalikes folded and obfuscation removed.
+func normalize(s string) string {
+	s = taphone.Deobfuscate(taphone.FoldConfusables(s))
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.Is(unicode.M, r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// isMixedScript reports whether s has letters from more than one script.
+func isMixedScript(s string) bool {
+	seen := -1
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		sc := len(scripts)
+		for i, t := range scripts {
+			if unicode.Is(t, r) {
+				sc = i
+				break
+			}
+		}
+		if seen > -1 && sc != seen {
+			return true
+		}
+		seen = sc
+	}
+	return false
+}
+
+// hasInvisible reports whether s has invisible formatting characters.
+func hasInvisible(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return unicode.Is(unicode.Cf, r)
+	}) > -1
+}
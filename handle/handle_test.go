@@ -0,0 +1,75 @@
+package handle
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+func TestCheck(t *testing.T) {
+	c := New(taphone.New(), []string{"முருகன்", "கண்ணன்", "rajakumar"})
+
+	cases := []struct {
+		name       string
+		handle     string
+		collisions []string
+		mixed      bool
+		invisible  bool
+		confusable bool
+	}{
+		{"existing name", "முருகன்", []string{"முருகன்"}, false, false, false},
+		{"soundalike", "முருகண்", []string{"முருகன்"}, false, false, false},
+		{"thanglish", "murugan", []string{"முருகன்"}, false, false, false},
+		{"thanglish with dots", "m.u.r.u.g.a.n", []string{"முருகன்"}, false, false, false},
+		{"tamil for a latin name", "ராஜகுமார்", []string{"rajakumar"}, false, false, false},
+		{"separators and digits", "raja_kumar99", []string{"rajakumar"}, false, false, false},
+		{"mixed script", "முருகan", nil, true, false, false},
+		{"tamil digit lookalike", "௧ண்ணன்", []string{"கண்ணன்"}, false, false, true},
+		{"zero width joiner", "கண்‍ணன்", []string{"கண்ணன்"}, false, true, false},
+		{"new name", "செல்வம்", nil, false, false, false},
+		{"empty", "", nil, false, false, false},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			r := c.Check(cs.handle)
+			var got []string
+			for _, x := range r.Collisions {
+				got = append(got, x.Name)
+			}
+			if !reflect.DeepEqual(got, cs.collisions) || r.MixedScript != cs.mixed ||
+				r.Invisible != cs.invisible || r.Confusable != cs.confusable {
+				t.Errorf("Check(%q) = %+v", cs.handle, r)
+			}
+			ok := cs.collisions == nil && !cs.mixed && !cs.invisible && !cs.confusable
+			if r.OK() != ok {
+				t.Errorf("Check(%q).OK() = %v, want %v", cs.handle, r.OK(), ok)
+			}
+		})
+	}
+}
+
+func TestCheckMinScore(t *testing.T) {
+	c := New(taphone.New(), []string{"முருகன்"})
+	c.MinScore = 0.9
+	if r := c.Check("முருகண்"); len(r.Collisions) > 0 {
+		t.Errorf("Check() = %+v with MinScore 0.9", r)
+	}
+
+	c.Add("முருகண்")
+	if r := c.Check("முருகண்"); len(r.Collisions) != 1 || r.Collisions[0].Score != 1 {
+		t.Errorf("Check() after Add = %+v", r)
+	}
+}
+
+func TestIsMixedScript(t *testing.T) {
+	cases := map[string]bool{
+		"முருகன்": false, "murugan": false, "முருகன்99": false, "முருகan": true,
+		"muruгan": true, "முருகന്": true, "": false,
+	}
+	for s, want := range cases {
+		if got := isMixedScript(s); got != want {
+			t.Errorf("isMixedScript(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
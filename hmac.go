@@ -0,0 +1,29 @@
+package taphone
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// EncodeHMAC encodes a unicode Tamil string and returns keyed hashes of
+// its three keys: hex encoded HMAC-SHA256 digests of key0, key1 and key2
+// under the secret key. Parties that share the secret can match records
+// phonetically by comparing the hashes without revealing the words.
+// Each level is hashed with its own domain, so that equal keys at
+// different levels don't produce equal hashes. Empty keys hash to "".
+func (k *TAphone) EncodeHMAC(word string, key []byte) (string, string, string) {
+	keys := k.keys(word)
+
+	var out [3]string
+	for l, s := range keys {
+		if s == "" {
+			continue
+		}
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte{'k', byte('0' + l), 0})
+		h.Write([]byte(s))
+		out[l] = hex.EncodeToString(h.Sum(nil))
+	}
+	return out[Key0], out[Key1], out[Key2]
+}
@@ -0,0 +1,65 @@
+package taphone
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestEncodeHMAC(t *testing.T) {
+	var (
+		k      = New()
+		secret = []byte("secret")
+	)
+	hash := func(level byte, key string) string {
+		h := hmac.New(sha256.New, secret)
+		h.Write([]byte{'k', level, 0})
+		h.Write([]byte(key))
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	cases := []struct {
+		name string
+		word string
+		want [3]string
+	}{
+		{"word", "வணக்கம்", [3]string{hash('0', "VNKKM"), hash('1', "VNKKM"), hash('2', "VNKKM")}},
+		{"no key", "hello", [3]string{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h0, h1, h2 := k.EncodeHMAC(c.word, secret)
+			if got := [3]string{h0, h1, h2}; got != c.want {
+				t.Errorf("EncodeHMAC(%q) = %q, want %q", c.word, got, c.want)
+			}
+		})
+	}
+
+	pairs := []struct {
+		name  string
+		a, b  string
+		key   []byte
+		equal [3]bool
+	}{
+		{"same word", "கடல்", "கடல்", secret, [3]bool{true, true, true}},
+		{"soundalikes", "கடல்", "கடள்", secret, [3]bool{true, true, true}},
+		{"key0 and key1", "கொடு", "கடு", secret, [3]bool{true, true, false}},
+		{"key0 only", "கரம்", "கறம்", secret, [3]bool{true, false, false}},
+		{"different secret", "கடல்", "கடல்", []byte("other"), [3]bool{}},
+	}
+	for _, c := range pairs {
+		t.Run(c.name, func(t *testing.T) {
+			a0, a1, a2 := k.EncodeHMAC(c.a, secret)
+			b0, b1, b2 := k.EncodeHMAC(c.b, c.key)
+			if got := [3]bool{a0 == b0, a1 == b1, a2 == b2}; got != c.equal {
+				t.Errorf("hashes of %q and %q equal = %v, want %v", c.a, c.b, got, c.equal)
+			}
+		})
+	}
+
+	// Equal keys at different levels hash differently.
+	if h0, h1, _ := k.EncodeHMAC("வணக்கம்", secret); h0 == h1 {
+		t.Error("key0 and key1 hashes are equal")
+	}
+}
@@ -0,0 +1,280 @@
+package taphone
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// affix is a single prefix or suffix rule from a Hunspell .aff file.
+type affix struct {
+	prefix bool
+	cross  bool
+	strip  string
+	add    string
+	flags  []string
+	cond   *regexp.Regexp
+}
+
+// affixFile holds the parts of a Hunspell .aff file that are needed
+// to expand dictionary stems into word forms.
+type affixFile struct {
+	flagType string
+	aliases  [][]string
+	rules    map[string][]affix
+}
+
+// LoadHunspell expands the stems in a Hunspell .dic file with the affix
+// rules of its .aff file and adds every resulting word form to the
+// dictionary with a frequency of 1. Only the affix (PFX, SFX), flag
+// (FLAG) and flag alias (AF) directives are interpreted; continuation
+// classes on suffixes are applied one level deep.
+func (d *Dictionary) LoadHunspell(dic, aff io.Reader) error {
+	a, err := parseAffixFile(aff)
+	if err != nil {
+		return fmt.Errorf("error reading .aff: %v", err)
+	}
+
+	p, dic := d.enc.newProgress(dic)
+	sc := bufio.NewScanner(dic)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	n := 0
+	for sc.Scan() {
+		n++
+		line := strings.TrimSpace(sc.Text())
+
+		// The first line is the approximate word count.
+		if n == 1 || line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Strip morphological fields.
+		if i := strings.IndexAny(line, " \t"); i > -1 {
+			line = line[:i]
+		}
+
+		word, flags := line, ""
+		if i := strings.Index(line, "/"); i > -1 {
+			word, flags = line[:i], line[i+1:]
+		}
+		for _, w := range a.expand(word, a.parseFlags(flags)) {
+			d.Add(w, 1)
+		}
+		p.add(1)
+	}
+	p.finish()
+	return sc.Err()
+}
+
+func parseAffixFile(r io.Reader) (*affixFile, error) {
+	var (
+		a   = &affixFile{rules: make(map[string][]affix)}
+		sc  = bufio.NewScanner(r)
+		n   = 0
+		hdr = make(map[string]bool)
+	)
+	for sc.Scan() {
+		n++
+		f := strings.Fields(sc.Text())
+		if len(f) == 0 || strings.HasPrefix(f[0], "#") {
+			continue
+		}
+
+		switch f[0] {
+		case "FLAG":
+			if len(f) > 1 {
+				a.flagType = f[1]
+			}
+
+		case "AF":
+			// The first AF line holds the count of aliases that follow.
+			if len(f) < 2 {
+				continue
+			}
+			if !hdr["AF"] {
+				hdr["AF"] = true
+				continue
+			}
+			a.aliases = append(a.aliases, a.parseFlags(f[1]))
+
+		case "PFX", "SFX":
+			if len(f) < 4 {
+				return nil, fmt.Errorf("line %d: malformed affix", n)
+			}
+
+			// Header: PFX flag cross_product count
+			key := f[0] + f[1]
+			if !hdr[key] {
+				hdr[key] = true
+				cross := f[2] == "Y"
+				a.rules[f[1]] = append(a.rules[f[1]], affix{prefix: f[0] == "PFX", cross: cross})
+				continue
+			}
+
+			// Rule: PFX flag strip add[/flags] [condition]
+			rule, err := a.parseRule(f)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", n, err)
+			}
+			a.rules[f[1]] = append(a.rules[f[1]], rule)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	// Propagate the cross product setting from each header to its rules
+	// and drop the header placeholders.
+	for flag, rules := range a.rules {
+		var (
+			out   []affix
+			cross bool
+		)
+		for _, r := range rules {
+			if r.cond == nil {
+				cross = r.cross
+				continue
+			}
+			r.cross = cross
+			out = append(out, r)
+		}
+		a.rules[flag] = out
+	}
+	return a, nil
+}
+
+func (a *affixFile) parseRule(f []string) (affix, error) {
+	r := affix{prefix: f[0] == "PFX"}
+
+	if f[2] != "0" {
+		r.strip = f[2]
+	}
+
+	add := f[3]
+	if i := strings.Index(add, "/"); i > -1 {
+		r.flags = a.parseFlags(add[i+1:])
+		add = add[:i]
+	}
+	if add != "0" {
+		r.add = add
+	}
+
+	cond := "."
+	if len(f) > 4 {
+		cond = f[4]
+	}
+	expr := "(?:" + cond + ")$"
+	if r.prefix {
+		expr = "^(?:" + cond + ")"
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return r, fmt.Errorf("invalid condition %q: %v", cond, err)
+	}
+	r.cond = re
+	return r, nil
+}
+
+// parseFlags splits a flag string according to the FLAG type of the file.
+func (a *affixFile) parseFlags(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	// Numeric flag strings may be aliases.
+	if len(a.aliases) > 0 {
+		if i, err := strconv.Atoi(s); err == nil && i > 0 && i <= len(a.aliases) {
+			return a.aliases[i-1]
+		}
+	}
+
+	var out []string
+	switch a.flagType {
+	case "long":
+		r := []rune(s)
+		for i := 0; i+1 < len(r); i += 2 {
+			out = append(out, string(r[i:i+2]))
+		}
+	case "num":
+		out = strings.Split(s, ",")
+	default:
+		for _, c := range s {
+			out = append(out, string(c))
+		}
+	}
+	return out
+}
+
+// expand returns the word and all forms derived from it by the given flags.
+func (a *affixFile) expand(word string, flags []string) []string {
+	var (
+		out      = []string{word}
+		suffixed = []string{word}
+	)
+
+	// Suffixes, with one level of continuation classes.
+	for _, flag := range flags {
+		for _, r := range a.rules[flag] {
+			if r.prefix {
+				continue
+			}
+			w, ok := r.apply(word)
+			if !ok {
+				continue
+			}
+			out = append(out, w)
+			if r.cross {
+				suffixed = append(suffixed, w)
+			}
+			for _, cf := range r.flags {
+				for _, cr := range a.rules[cf] {
+					if cr.prefix {
+						continue
+					}
+					if cw, ok := cr.apply(w); ok {
+						out = append(out, cw)
+					}
+				}
+			}
+		}
+	}
+
+	// Prefixes, cross combined with suffixed forms where permitted.
+	for _, flag := range flags {
+		for _, r := range a.rules[flag] {
+			if !r.prefix {
+				continue
+			}
+			bases := []string{word}
+			if r.cross {
+				bases = suffixed
+			}
+			for _, b := range bases {
+				if w, ok := r.apply(b); ok {
+					out = append(out, w)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// apply applies the affix rule to word if its condition matches.
+func (r affix) apply(word string) (string, bool) {
+	if !r.cond.MatchString(word) {
+		return "", false
+	}
+	if r.prefix {
+		if !strings.HasPrefix(word, r.strip) {
+			return "", false
+		}
+		return r.add + word[len(r.strip):], true
+	}
+	if !strings.HasSuffix(word, r.strip) {
+		return "", false
+	}
+	return word[:len(word)-len(r.strip)] + r.add, true
+}
@@ -0,0 +1,88 @@
+package taphone
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestLoadHunspell(t *testing.T) {
+	cases := []struct {
+		name string
+		aff  string
+		dic  string
+		want []string
+	}{
+		{"suffixes",
+			"SFX A Y 2\nSFX A 0 கள் .\nSFX A ் ை ்\n",
+			"2\nமரம்/A\nகடல்\n",
+			[]string{"கடல்", "மரமை", "மரம்", "மரம்கள்"}},
+		{"condition",
+			"SFX A N 1\nSFX A ் ும் [லன]்\n",
+			"2\nகடல்/A\nமரம்/A\n",
+			[]string{"கடலும்", "கடல்", "மரம்"}},
+		{"cross product prefix",
+			"PFX P Y 1\nPFX P 0 அ .\nSFX S Y 1\nSFX S 0 ு .\n",
+			"1\nகடல்/PS\n",
+			[]string{"அகடல்", "அகடல்ு", "கடல்", "கடல்ு"}},
+		{"prefix without cross product",
+			"PFX P N 1\nPFX P 0 அ .\nSFX S Y 1\nSFX S 0 ு .\n",
+			"1\nகடல்/PS\n",
+			[]string{"அகடல்", "கடல்", "கடல்ு"}},
+		{"continuation class",
+			"SFX A Y 1\nSFX A 0 கள்/B .\nSFX B Y 1\nSFX B 0 ஐ .\n",
+			"1\nமரம்/A\n",
+			[]string{"மரம்", "மரம்கள்", "மரம்கள்ஐ"}},
+		{"long flags",
+			"FLAG long\nSFX Aa Y 1\nSFX Aa 0 கள் .\n",
+			"1\nமரம்/Aa\n",
+			[]string{"மரம்", "மரம்கள்"}},
+		{"numeric flags",
+			"FLAG num\nSFX 12 Y 1\nSFX 12 0 கள் .\n",
+			"1\nமரம்/12\n",
+			[]string{"மரம்", "மரம்கள்"}},
+		{"flag aliases",
+			"AF 1\nAF A\nSFX A Y 1\nSFX A 0 கள் .\n",
+			"1\nமரம்/1\n",
+			[]string{"மரம்", "மரம்கள்"}},
+		{"morphological fields and comments",
+			"SFX A Y 1\nSFX A 0 கள் .\n",
+			"2\n# comment\nமரம்/A po:noun\n\n",
+			[]string{"மரம்", "மரம்கள்"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := NewDictionary(New())
+			if err := d.LoadHunspell(strings.NewReader(c.dic), strings.NewReader(c.aff)); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for w := range d.words {
+				got = append(got, w)
+			}
+			sort.Strings(got)
+			if strings.Join(got, " ") != strings.Join(c.want, " ") {
+				t.Errorf("words = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestLoadHunspellErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		aff  string
+		err  string
+	}{
+		{"malformed affix", "SFX A Y 1\nSFX A 0\n", "line 2: malformed affix"},
+		{"invalid condition", "SFX A Y 1\nSFX A 0 கள் [க\n", `invalid condition "[க"`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := NewDictionary(New()).LoadHunspell(strings.NewReader("1\nமரம்/A\n"), strings.NewReader(c.aff))
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("LoadHunspell() error = %v, want %q", err, c.err)
+			}
+		})
+	}
+}
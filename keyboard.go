@@ -0,0 +1,194 @@
+package taphone
+
+import (
+	"math"
+)
+
+// keyRows are the rows of a US QWERTY keyboard, unshifted and shifted,
+// with the horizontal offset of each row's first key.
+var keyRows = []struct {
+	keys    string
+	shifted string
+	offset  float64
+}{
+	{"`1234567890-=", "~!@#$%^&*()_+", 0},
+	{"qwertyuiop[]\\", "QWERTYUIOP{}|", 0.5},
+	{"asdfghjkl;'", "ASDFGHJKL:\"", 0.75},
+	{"zxcvbnm,./", "ZXCVBNM<>?", 1.25},
+}
+
+// keyPos is the position of a physical key on the keyboard grid.
+type keyPos struct {
+	x, y    float64
+	shifted bool
+}
+
+// KeyboardLayout is a Tamil keyboard layout that maps the physical keys
+// of a QWERTY keyboard to the Tamil characters they type.
+type KeyboardLayout struct {
+	Name string
+
+	// keys maps each QWERTY key to the Tamil text it types.
+	keys map[rune]string
+
+	// pos maps each Tamil character to the key that types it.
+	pos map[rune]keyPos
+
+	// signs maps vowels to the vowel signs their keys type after a
+	// consonant, on layouts that type vowel signs with the vowel keys.
+	signs map[string]string
+}
+
+// Tamil99 is the Tamil99 keyboard layout standardised by the Government
+// of Tamil Nadu. Vowel signs are typed with their vowel keys.
+var Tamil99 = newKeyboardLayout("tamil99", map[rune]string{
+	'q': "ஆ", 'w': "ஈ", 'e': "ஊ", 'r': "ஐ", 't': "ஏ", 'y': "ள", 'u': "ற",
+	'i': "ன", 'o': "ட", 'p': "ண", '[': "ச", ']': "ஞ",
+	'a': "அ", 's': "இ", 'd': "உ", 'f': "்", 'g': "எ", 'h': "க", 'j': "ப",
+	'k': "ம", 'l': "த", ';': "ந", '\'': "ய",
+	'z': "ஔ", 'x': "ஓ", 'c': "ஒ", 'v': "வ", 'b': "ங", 'n': "ல", 'm': "ர",
+	'/': "ழ",
+	'Q': "ஸ", 'W': "ஷ", 'E': "ஜ", 'R': "ஹ", 'T': "க்ஷ", 'Y': "ஸ்ரீ", 'F': "ஃ",
+}, map[rune]rune{
+	'ா': 'q', 'ீ': 'w', 'ூ': 'e', 'ை': 'r', 'ே': 't', 'ி': 's', 'ு': 'd',
+	'ெ': 'g', 'ௌ': 'z', 'ோ': 'x', 'ொ': 'c',
+})
+
+// InScript is the Tamil variant of the Indian standard InScript layout.
+var InScript = newKeyboardLayout("inscript", map[rune]string{
+	'`': "ொ", 'q': "ௌ", 'w': "ை", 'e': "ா", 'r': "ீ", 't': "ூ", 'u': "ஹ",
+	'p': "ஜ",
+	'a': "ோ", 's': "ே", 'd': "்", 'f': "ி", 'g': "ு", 'h': "ப", 'j': "ர",
+	'k': "க", 'l': "த", ';': "ச", '\'': "ட",
+	'z': "ெ", 'x': "ஂ", 'c': "ம", 'v': "ந", 'b': "வ", 'n': "ல", 'm': "ஸ",
+	'/': "ய",
+	'~': "ஒ", 'Q': "ஔ", 'W': "ஐ", 'E': "ஆ", 'R': "ஈ", 'T': "ஊ", 'U': "ங",
+	'}': "ஞ",
+	'A': "ஓ", 'S': "ஏ", 'D': "அ", 'F': "இ", 'G': "உ", 'J': "ற",
+	'Z': "எ", 'C': "ண", 'V': "ன", 'B': "ழ", 'N': "ள", 'M': "ஶ", '<': "ஷ",
+}, nil)
+
+// newKeyboardLayout builds a layout from its key map and additional
+// characters (typed by context, like Tamil99 vowel signs) mapped to keys.
+func newKeyboardLayout(name string, keys map[rune]string, extra map[rune]rune) *KeyboardLayout {
+	grid := make(map[rune]keyPos)
+	for y, row := range keyRows {
+		for x, c := range row.keys {
+			grid[c] = keyPos{x: float64(x) + row.offset, y: float64(y)}
+		}
+		for x, c := range row.shifted {
+			grid[c] = keyPos{x: float64(x) + row.offset, y: float64(y), shifted: true}
+		}
+	}
+
+	l := &KeyboardLayout{Name: name, keys: keys, pos: make(map[rune]keyPos)}
+	for k, s := range keys {
+		if r := []rune(s); len(r) == 1 {
+			l.pos[r[0]] = grid[k]
+		}
+	}
+	for r, k := range extra {
+		l.pos[r] = grid[k]
+
+		if l.signs == nil {
+			l.signs = map[string]string{"அ": ""}
+		}
+		l.signs[keys[k]] = string(r)
+	}
+	return l
+}
+
+// keyDistance returns the distance between the keys that type a and b,
+// and false if either isn't on the layout. Characters on the same key
+// (shifted and unshifted) are half a key apart.
+func (l *KeyboardLayout) keyDistance(a, b rune) (float64, bool) {
+	pa, ok := l.pos[a]
+	if !ok {
+		return 0, false
+	}
+	pb, ok := l.pos[b]
+	if !ok {
+		return 0, false
+	}
+
+	d := math.Hypot(pa.x-pb.x, pa.y-pb.y)
+	if pa.shifted != pb.shifted {
+		d += 0.5
+	}
+	return d, true
+}
+
+// neighbours returns the characters typed by keys adjacent to the key
+// that types r.
+func (l *KeyboardLayout) neighbours(r rune) []rune {
+	var out []rune
+	for c := range l.pos {
+		if c == r {
+			continue
+		}
+		if d, ok := l.keyDistance(r, c); ok && d <= 1.5 {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// substitutionCost returns the cost of mistyping a as b. Adjacent keys
+// are cheaper to confuse than distant ones.
+func (l *KeyboardLayout) substitutionCost(a, b rune) float64 {
+	if a == b {
+		return 0
+	}
+	d, ok := l.keyDistance(a, b)
+	if !ok {
+		return 1
+	}
+	return math.Min(1, 0.3+0.35*d)
+}
+
+// TypoSimilarity returns the likelihood, between 0 and 1, that b is a
+// fat-finger mistyping of a on this layout. It is a Levenshtein
+// similarity where substitutions between nearby keys cost less.
+func (l *KeyboardLayout) TypoSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	max := len(ra)
+	if len(rb) > max {
+		max = len(rb)
+	}
+	if max == 0 {
+		return 1
+	}
+
+	prev := make([]float64, len(rb)+1)
+	cur := make([]float64, len(rb)+1)
+	for j := range prev {
+		prev[j] = float64(j)
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = float64(i)
+		for j := 1; j <= len(rb); j++ {
+			cur[j] = math.Min(math.Min(prev[j]+1, cur[j-1]+1),
+				prev[j-1]+l.substitutionCost(ra[i-1], rb[j-1]))
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - prev[len(rb)]/float64(max)
+}
+
+// typoVariants returns the strings obtained by replacing one character
+// of s with a character on an adjacent key.
+func (l *KeyboardLayout) typoVariants(s string) []string {
+	var (
+		r   = []rune(s)
+		out []string
+	)
+	for i, c := range r {
+		for _, n := range l.neighbours(c) {
+			v := make([]rune, len(r))
+			copy(v, r)
+			v[i] = n
+			out = append(out, string(v))
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTypoSimilarity(t *testing.T) {
+	cases := []struct {
+		name   string
+		layout *KeyboardLayout
+		a, b   string
+		min    float64
+		max    float64
+	}{
+		{"identical", Tamil99, "மரம்", "மரம்", 1, 1},
+		{"empty", Tamil99, "", "", 1, 1},
+		{"adjacent keys", Tamil99, "மரம்", "பரம்", 0.8, 0.9},
+		{"distant keys", Tamil99, "மரம்", "ழரம்", 0.74, 0.76},
+		{"vowel sign keys", Tamil99, "கிளி", "குளி", 0.8, 0.95},
+		{"inscript adjacent keys", InScript, "கடல்", "தடல்", 0.8, 0.9},
+		{"off the layout", Tamil99, "மரம்", "xரம்", 0.74, 0.76},
+		{"insertion", Tamil99, "மரம்", "மரம்ம", 0.79, 0.81},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.layout.TypoSimilarity(c.a, c.b); got < c.min || got > c.max {
+				t.Errorf("%s.TypoSimilarity(%q, %q) = %v, want in [%v, %v]", c.layout.Name, c.a, c.b, got, c.min, c.max)
+			}
+		})
+	}
+}
+
+func TestSuggestKeyboard(t *testing.T) {
+	cases := []struct {
+		name   string
+		layout *KeyboardLayout
+		word   string
+		want   []string
+	}{
+		{"no layout", nil, "பரம்", nil},
+		{"tamil99 typo", Tamil99, "பரம்", []string{"மரம்"}},
+		{"inscript typo", InScript, "தடல்", []string{"கடல்"}},
+		{"distant key", Tamil99, "ழரம்", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := NewDictionary(New())
+			d.Add("மரம்", 1)
+			d.Add("கடல்", 1)
+			d.SetKeyboard(c.layout)
+			if got := suggestionWords(d.Suggest(c.word, 0)); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Suggest(%q) = %q, want %q", c.word, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package taphone
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// confidenceMergePenalty is the confidence lost by a key whose letters and
+// vowel signs all share their codes with others.
+const confidenceMergePenalty = 0.4
+
+// Keys are the three phonetic keys of a word with a confidence score for
+// each.
+type Keys struct {
+	Key0 string
+	Key1 string
+	Key2 string
+
+	// Canonical is the Tamil text that the keys were encoded from: the
+	// input after preprocessing (profiles, normalisation, deobfuscation)
+	// with the characters that the encoder ignores removed and words
+	// separated by single spaces. It can be stored and displayed as what
+	// the keys stand for.
+	Canonical string
+
+	// Confidence holds the confidence of each key between 0 and 1,
+	// indexed by KeyLevel. It is lowered by preprocessing that rewrote
+	// the input (profiles, OCR and dialect normalisation, expansions), by
+	// letters that were dropped rather than encoded (Grantha letters
+	// unless WithLoanSounds is set, other scripts) and by letters and
+	// vowel signs whose codes the key shares with others (ண and ந, ி and
+	// ீ at key2, and more at lower levels).
+	Confidence [3]float64
+}
+
+// Key returns the key at a level.
+func (k Keys) Key(level KeyLevel) string {
+	switch level {
+	case Key0:
+		return k.Key0
+	case Key1:
+		return k.Key1
+	}
+	return k.Key2
+}
+
+var (
+	mergedOnce sync.Once
+
+	// merged holds, for each level, the letters and vowel signs that
+	// share their code at that level with another letter or sign.
+	merged [3]map[rune]bool
+)
+
+// EncodeKeys encodes a unicode Tamil string and returns its keys with
+// their confidence scores and the canonical text they were encoded from.
+func (k *TAphone) EncodeKeys(input string) Keys {
+	var (
+		pre  = k.preprocess(input)
+		keys = k.encode(pre)
+		out  = Keys{Key0: keys[Key0], Key1: keys[Key1], Key2: keys[Key2], Canonical: canonical([]rune(pre))}
+	)
+	if keys[Key0] != "" {
+		out.Confidence = k.confidence([]rune(input), []rune(pre))
+	}
+	return out
+}
+
+// EncodeRunes encodes a rune slice, such as an editor's or input method's
+// buffer, and returns its keys with their confidence scores, like
+// EncodeKeys. The runes are encoded directly without converting the
+// slice to a string, unless the encoder has preprocessing filters or
+// key options that work on text (disambiguation, length buckets and
+// n-gram fallbacks). The slice is not modified.
+func (k *TAphone) EncodeRunes(r []rune) Keys {
+	if len(k.filters) > 0 || k.disambiguate || k.lengthBuckets || k.ngram > 0 {
+		return k.EncodeKeys(string(r))
+	}
+
+	var (
+		keys = k.derive("", k.processRunes(r), true)
+		out  = Keys{Key0: keys[Key0], Key1: keys[Key1], Key2: keys[Key2], Canonical: canonical(r)}
+	)
+	if keys[Key0] != "" {
+		out.Confidence = k.confidence(r, r)
+	}
+	return out
+}
+
+// confidence returns the confidence of the keys of input at each level,
+// given the input after preprocessing.
+func (k *TAphone) confidence(input, pre []rune) [3]float64 {
+	// Preprocessing and dropped letters lower all levels alike.
+	base := 0.5 + 0.5*similarity(input, pre)
+	var letters, dropped int
+	for _, r := range pre {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if _, ok := consonants[string(r)]; ok {
+			continue
+		}
+		if _, ok := vowels[string(r)]; !ok && !(k.loanSounds && isLoanLetter(r)) {
+			dropped++
+		}
+	}
+	if letters > 0 {
+		base *= 1 - float64(dropped)/float64(letters)
+	}
+
+	mergedOnce.Do(initMerged)
+	var out [3]float64
+	for l := range out {
+		var n, units int
+		for _, r := range pre {
+			if consonants[string(r)] == "" && vowels[string(r)] == "" && modifiers[string(r)] == "" && r != 'ா' {
+				continue
+			}
+			units++
+			if merged[l][r] {
+				n++
+			}
+		}
+		out[l] = base
+		if units > 0 {
+			out[l] *= 1 - confidenceMergePenalty*float64(n)/float64(units)
+		}
+	}
+	return out
+}
+
+// initMerged builds merged by encoding every letter and vowel sign that
+// the tables know of. Letters are compared with letters, and vowel signs,
+// encoded on க, with the other signs and the bare க.
+func initMerged() {
+	var (
+		letters = make(map[rune]string)
+		// The bare consonant is keyed under 0 so that it is compared with
+		// the signs but not counted as merged itself.
+		signs = map[rune]string{0: "க"}
+	)
+	for v := range vowels {
+		letters[[]rune(v)[0]] = v
+	}
+	for c := range consonants {
+		letters[[]rune(c)[0]] = c
+	}
+	for m := range modifiers {
+		signs[[]rune(m)[0]] = "க" + m
+	}
+
+	enc := New()
+	for l := range merged {
+		merged[l] = make(map[rune]bool)
+	}
+	for _, units := range []map[rune]string{letters, signs} {
+		var codes [3]map[string][]rune
+		for l := range codes {
+			codes[l] = make(map[string][]rune)
+		}
+		for r, s := range units {
+			for l, key := range enc.encode(s) {
+				codes[l][key] = append(codes[l][key], r)
+			}
+		}
+		for l := range codes {
+			for _, rs := range codes[l] {
+				if len(rs) < 2 {
+					continue
+				}
+				for _, r := range rs {
+					if r != 0 {
+						merged[l][r] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+// isLoanLetter reports whether r is a letter that is coded as a loan
+// sound with WithLoanSounds.
+func isLoanLetter(r rune) bool {
+	return strings.ContainsRune("ஃஜஷஸஶஹ", r)
+}
+
+// canonical removes the characters that the encoder ignores from
+// preprocessed input, keeping single spaces between words.
+func canonical(pre []rune) string {
+	var (
+		out   strings.Builder
+		space bool
+	)
+	for _, r := range pre {
+		switch {
+		case unicode.IsSpace(r):
+			space = out.Len() > 0
+		case unicode.Is(unicode.Tamil, r):
+			if space {
+				out.WriteByte(' ')
+				space = false
+			}
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,121 @@
+package taphone
+
+import "testing"
+
+func TestEncodeKeys(t *testing.T) {
+	cases := []string{"மரம்", "கண்ணன்", "ஜெயா", "திரு. முருகன்", "வணக்கம்abc", "hello", ""}
+	for _, k := range []*TAphone{New(), New(WithLoanSounds()), New(WithProfile(NameProfile))} {
+		for _, input := range cases {
+			keys := k.EncodeKeys(input)
+			k0, k1, k2 := k.Encode(input)
+			if keys.Key0 != k0 || keys.Key1 != k1 || keys.Key2 != k2 {
+				t.Errorf("EncodeKeys(%q) = %q, %q, %q, want Encode's %q, %q, %q",
+					input, keys.Key0, keys.Key1, keys.Key2, k0, k1, k2)
+			}
+			for l := Key0; l <= Key2; l++ {
+				if c := keys.Confidence[l]; c < 0 || c > 1 {
+					t.Errorf("EncodeKeys(%q) key%d confidence = %v, want 0 to 1", input, l, c)
+				}
+				if keys.Key(l) != [3]string{k0, k1, k2}[l] {
+					t.Errorf("Key(%d) = %q", l, keys.Key(l))
+				}
+			}
+		}
+	}
+}
+
+func TestConfidence(t *testing.T) {
+	var (
+		plain = New()
+		loans = New(WithLoanSounds())
+		names = New(WithProfile(NameProfile))
+	)
+	// Each case's first word has lower confidence at every level than
+	// its second.
+	cases := []struct {
+		name   string
+		ka, kb *TAphone
+		a, b   string
+	}{
+		{"merged letters", plain, plain, "கண்ணன்", "மரம்"},
+		{"dropped grantha", plain, loans, "ஜெயா", "ஜெயா"},
+		{"other scripts", plain, plain, "வணக்கம்abc", "வணக்கம்"},
+		{"preprocessing", names, plain, "திரு. முருகன்", "முருகன்"},
+		{"no key", plain, plain, "hello", "மரம்"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, b := c.ka.EncodeKeys(c.a).Confidence, c.kb.EncodeKeys(c.b).Confidence
+			for l := range a {
+				if a[l] >= b[l] {
+					t.Errorf("key%d confidence of %q = %v, of %q = %v, want lower", l, c.a, a[l], c.b, b[l])
+				}
+			}
+		})
+	}
+
+	if c := plain.EncodeKeys("மரம்").Confidence; c[Key1] != 1 || c[Key2] != 1 || c[Key0] >= 1 {
+		t.Errorf("confidence of மரம் = %v, want 1 at key1 and key2 only", c)
+	}
+	if c := plain.EncodeKeys("hello").Confidence; c != [3]float64{} {
+		t.Errorf("confidence of hello = %v, want zero", c)
+	}
+}
+
+func TestEncodeRunes(t *testing.T) {
+	cases := []struct {
+		name string
+		opts []Option
+	}{
+		{"default", nil},
+		{"loan sounds", []Option{WithLoanSounds()}},
+		{"filters", []Option{WithProfile(NameProfile)}},
+		{"key options", []Option{WithLengthBuckets(), WithDisambiguation()}},
+	}
+	inputs := []string{"வணக்கம்", "கல்", "ஜெயா", "திரு. முருகன்", "வணக்கம்abc", "hello", ""}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k := New(c.opts...)
+			for _, input := range inputs {
+				r := []rune(input)
+				if got, want := k.EncodeRunes(r), k.EncodeKeys(input); got != want {
+					t.Errorf("EncodeRunes(%q) = %+v, want %+v", input, got, want)
+				}
+				if string(r) != input {
+					t.Errorf("EncodeRunes(%q) modified its input to %q", input, string(r))
+				}
+			}
+		})
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  []Option
+		input string
+		want  string
+	}{
+		{"plain", nil, "வணக்கம்", "வணக்கம்"},
+		{"spaces", nil, "  மரம்   கல் ", "மரம் கல்"},
+		{"other scripts", nil, "வணக்கம்abc 12!", "வணக்கம்"},
+		{"only other scripts", nil, "hello", ""},
+		{"profile", []Option{WithProfile(NameProfile)}, "திரு. ஜெயா", "செயா"},
+		{"archaic", []Option{WithArchaicNormalization()}, "கெளரி", "கௌரி"},
+		{"braille", []Option{WithBraille()}, "⠅⠁⠍⠇⠈", "கமல்"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k := New(c.opts...)
+			if got := k.EncodeKeys(c.input).Canonical; got != c.want {
+				t.Errorf("EncodeKeys(%q).Canonical = %q, want %q", c.input, got, c.want)
+			}
+
+			// The canonical text encodes to the same keys.
+			keys, canon := k.EncodeKeys(c.input), New().EncodeKeys(c.want)
+			if keys.Key0 != canon.Key0 || keys.Key1 != canon.Key1 || keys.Key2 != canon.Key2 {
+				t.Errorf("keys of %q = %+v, of its canonical text %+v", c.input, keys, canon)
+			}
+		})
+	}
+}
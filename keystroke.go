@@ -0,0 +1,122 @@
+package taphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// autoPulli are the consonant pairs after which Tamil99 inserts the
+// virama automatically: geminates and the nasal-stop clusters.
+var autoPulli = map[[2]string]bool{
+	{"ங", "க"}: true, {"ஞ", "ச"}: true, {"ண", "ட"}: true, {"ந", "த"}: true,
+	{"ம", "ப"}: true, {"ன", "ற"}: true,
+}
+
+// signRecombine maps split two part vowel signs to the combined signs.
+var signRecombine = map[string]string{"ொ": "ொ", "ோ": "ோ", "ௌ": "ௌ"}
+
+// Type converts a sequence of keystrokes on the layout, as recorded by an
+// input method, to the Tamil text it types. On Tamil99, vowel keys type
+// vowel signs after consonants and the virama is inserted automatically
+// in geminates and nasal-stop clusters (hhd → க்கு, bh → ங்க). Keys
+// that aren't on the layout are copied as is. The text is then
+// normalised with NormalizeSigns to repair mis-ordered vowel signs.
+func (l *KeyboardLayout) Type(keystrokes string) string {
+	var (
+		b    strings.Builder
+		cons string
+	)
+	for _, k := range keystrokes {
+		s, ok := l.keys[k]
+		if !ok {
+			b.WriteRune(k)
+			cons = ""
+			continue
+		}
+
+		if l.signs != nil && cons != "" {
+			if sign, ok := l.signs[s]; ok {
+				b.WriteString(sign)
+				cons = ""
+				continue
+			}
+			if cons == s || autoPulli[[2]string{cons, s}] {
+				b.WriteString("்")
+			}
+		}
+
+		b.WriteString(s)
+		cons = ""
+		if isConsonant(s) {
+			cons = s
+		}
+	}
+	return NormalizeSigns(b.String())
+}
+
+// NormalizeSigns repairs vowel signs typed out of order. The prebase
+// signs ெ, ே and ை are drawn before their consonant and often typed
+// before it too (ெக → கெ), two part signs are typed as their parts
+// (கெ + ா → கொ) and signs with no consonant to attach to, as in half
+// typed words, are dropped.
+func NormalizeSigns(s string) string {
+	var (
+		in  = []rune(s)
+		out = make([]rune, 0, len(in))
+	)
+	for i := 0; i < len(in); i++ {
+		r := in[i]
+		if !isVowelSign(r) {
+			out = append(out, r)
+			continue
+		}
+
+		// A sign must follow a consonant. A prebase sign before a
+		// consonant belongs to it.
+		attached := len(out) > 0 && isConsonant(string(out[len(out)-1]))
+		if !attached {
+			if i+1 < len(in) && isConsonant(string(in[i+1])) && strings.ContainsRune("ெேை", r) {
+				out = append(out, in[i+1], r)
+				i++
+			} else {
+				continue
+			}
+		} else {
+			out = append(out, r)
+		}
+
+		// Recombine two part signs.
+		if i+1 < len(in) && len(out) > 0 {
+			if c, ok := signRecombine[string(out[len(out)-1])+string(in[i+1])]; ok {
+				out[len(out)-1] = []rune(c)[0]
+				i++
+			}
+		}
+	}
+	return string(out)
+}
+
+// WithKeystrokes converts input recorded as keystrokes on a layout to
+// Tamil text with Type before it is encoded.
+func WithKeystrokes(l *KeyboardLayout) Option {
+	return func(k *TAphone) {
+		k.addFilter("keystrokes:"+l.Name, l.Type)
+	}
+}
+
+// isConsonant reports whether s is a single Tamil consonant letter.
+func isConsonant(s string) bool {
+	if _, ok := consonants[s]; ok {
+		return true
+	}
+	switch s {
+	case "ஜ", "ஷ", "ஸ", "ஶ", "ஹ":
+		return true
+	}
+	return false
+}
+
+// isVowelSign reports whether r is a Tamil vowel sign or the virama.
+func isVowelSign(r rune) bool {
+	return unicode.Is(unicode.Tamil, r) && unicode.Is(unicode.M, r) && r != 'ஂ'
+}
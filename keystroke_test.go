@@ -0,0 +1,58 @@
+package taphone
+
+import "testing"
+
+func TestType(t *testing.T) {
+	cases := []struct {
+		name       string
+		layout     *KeyboardLayout
+		keystrokes string
+		want       string
+	}{
+		{"tamil99 word", Tamil99, "vphhkf", "வணக்கம்"},
+		{"tamil99 vowel sign", Tamil99, "kq", "மா"},
+		{"tamil99 initial vowel", Tamil99, "av", "அவ"},
+		{"tamil99 geminate", Tamil99, "hhd", "க்கு"},
+		{"tamil99 nasal stop", Tamil99, "bh", "ங்க"},
+		{"tamil99 explicit virama", Tamil99, "hfh", "க்க"},
+		{"tamil99 unknown keys", Tamil99, "kq 12", "மா 12"},
+		{"inscript word", InScript, "bCkdkcd", "வணக்கம்"},
+		{"inscript two part sign", InScript, "kze", "கொ"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.layout.Type(c.keystrokes); got != c.want {
+				t.Errorf("%s.Type(%q) = %q, want %q", c.layout.Name, c.keystrokes, got, c.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeSigns(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"கெ", "கெ"},
+		{"ெக", "கெ"},
+		{"கொ", "கொ"},
+		{"ெகா", "கொ"},
+		{"கெள", "கெள"},
+		{"கௌ", "கௌ"},
+		{"ிக", "க"},
+		{"அி", "அ"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		if got := NormalizeSigns(c.input); got != c.want {
+			t.Errorf("NormalizeSigns(%q) = %q, want %q", c.input, got, c.want)
+		}
+	}
+}
+
+func TestWithKeystrokes(t *testing.T) {
+	k := New(WithKeystrokes(Tamil99))
+	if a, b := k.EncodeLevel("vphhkf", Key2), New().EncodeLevel("வணக்கம்", Key2); a != b {
+		t.Errorf("key2 of keystrokes = %q, want %q", a, b)
+	}
+}
@@ -0,0 +1,40 @@
+package taphone
+
+// Length buckets, as the number of letters (consonants, vowels and
+// consonant-vowel combinations) in a word.
+const (
+	lengthShort  = 3
+	lengthMedium = 6
+)
+
+// WithLengthBuckets prefixes key0 with a coarse length bucket of the word:
+// 4 for words of up to three letters, 5 for up to six letters and 6 for
+// longer words, counting consonant-vowel combinations as one letter
+// (வணக்கம் → 5VNKKM). Short and long words that share a consonant
+// skeleton then no longer share key0, which reduces false matches in
+// high recall searches. The bucket digits are ones that key0 doesn't
+// otherwise use.
+func WithLengthBuckets() Option {
+	return func(k *TAphone) {
+		k.lengthBuckets = true
+	}
+}
+
+// lengthBucket returns the length bucket prefix of a word with the given
+// key0 codes. The length of a Latin word, keyed by EncodeRoman, is
+// estimated by the number of codes, as each consonant code stands for a
+// consonant with or without a vowel sign.
+func lengthBucket(word, codes string) string {
+	n := len(syllables(word))
+	if n == 0 {
+		toks, _ := tokenizeKey(codes)
+		n = len(toks)
+	}
+	switch {
+	case n <= lengthShort:
+		return "4"
+	case n <= lengthMedium:
+		return "5"
+	}
+	return "6"
+}
@@ -0,0 +1,50 @@
+package taphone
+
+import "testing"
+
+func TestWithLengthBuckets(t *testing.T) {
+	cases := []struct {
+		input string
+		key0  string
+		roman string
+	}{
+		{"கல்", "4KL", "kal"},
+		{"மரம்", "4MRM", "maram"},
+		{"வணக்கம்", "5VNKKM", "vanakkam"},
+		{"பள்ளிக்கூடம்", "6PLL3KKTM", "pallikkoodam"},
+		{"அ", "4A", "a"},
+		{"hello", "", ""},
+	}
+	k := New(WithLengthBuckets())
+	for _, c := range cases {
+		k0, k1, _ := k.Encode(c.input)
+		if k0 != c.key0 {
+			t.Errorf("key0 of %q = %q, want %q", c.input, k0, c.key0)
+		}
+		if k1 != New().EncodeLevel(c.input, Key1) {
+			t.Errorf("key1 of %q = %q, want it without a bucket", c.input, k1)
+		}
+		if got := k.EncodeRoman(c.roman); got != c.key0 {
+			t.Errorf("EncodeRoman(%q) = %q, want %q", c.roman, got, c.key0)
+		}
+	}
+}
+
+func TestLengthBucket(t *testing.T) {
+	cases := []struct {
+		word, codes string
+		want        string
+	}{
+		{"கலை", "KL", "4"},
+		{"பாடசாலை", "PTCL", "5"},
+		{"மகிழ்ச்சியுடன்", "MK3ZCC3YTN", "6"},
+		{"", "KL", "4"},
+		{"", "VNKKM", "5"},
+		{"", "PLL3KKTM", "6"},
+	}
+	for _, c := range cases {
+		if got := lengthBucket(c.word, c.codes); got != c.want {
+			t.Errorf("lengthBucket(%q, %q) = %q, want %q", c.word, c.codes, got, c.want)
+		}
+	}
+}
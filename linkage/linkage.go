@@ -0,0 +1,160 @@
+// Package linkage links person records, as found in government and
+// health datasets, by comparing their fields with taphone phonetic
+// similarity. Each field is scored separately and the scores are
+// combined with per-field weights into a match, probable match or
+// non-match classification.
+package linkage
+
+import (
+	"time"
+
+	"github.com/cmrajan/taphone"
+)
+
+// Class is the classification of a compared record pair.
+type Class int
+
+// Record pair classes.
+const (
+	NonMatch Class = iota
+	Probable
+	Match
+)
+
+// String returns the name of the class.
+func (c Class) String() string {
+	switch c {
+	case Match:
+		return "match"
+	case Probable:
+		return "probable"
+	}
+	return "non-match"
+}
+
+// Record is a person record. Empty fields (and a zero DOB) are unknown
+// and don't count towards the comparison.
+type Record struct {
+	Name       string
+	ParentName string
+	Village    string
+	DOB        time.Time
+}
+
+// Weights are the relative weights of the record fields in the combined
+// score.
+type Weights struct {
+	Name       float64
+	ParentName float64
+	Village    float64
+	DOB        float64
+}
+
+// DefaultWeights weigh names the most, followed by the date of birth.
+var DefaultWeights = Weights{
+	Name:       0.4,
+	ParentName: 0.2,
+	Village:    0.15,
+	DOB:        0.25,
+}
+
+// Default classification thresholds.
+const (
+	DefaultMatchThreshold    = 0.85
+	DefaultProbableThreshold = 0.65
+)
+
+// Result is the outcome of comparing two records.
+type Result struct {
+	// Score is the weighted combined score between 0 and 1.
+	Score float64
+	Class Class
+
+	// Fields holds the score of each field that was compared, keyed by
+	// field name (name, parent_name, village, dob).
+	Fields map[string]float64
+}
+
+// Linker compares person records.
+type Linker struct {
+	enc *taphone.TAphone
+
+	Weights           Weights
+	MatchThreshold    float64
+	ProbableThreshold float64
+}
+
+// New returns a Linker that compares names with the given encoder, using
+// the default weights and thresholds.
+func New(enc *taphone.TAphone) *Linker {
+	return &Linker{
+		enc:               enc,
+		Weights:           DefaultWeights,
+		MatchThreshold:    DefaultMatchThreshold,
+		ProbableThreshold: DefaultProbableThreshold,
+	}
+}
+
+// Compare compares two records and classifies the pair. Fields that are
+// unknown in either record are skipped and the weights of the remaining
+// fields are renormalised. A pair with no comparable fields is a
+// non-match.
+func (l *Linker) Compare(a, b Record) Result {
+	var (
+		res   = Result{Fields: make(map[string]float64)}
+		total float64
+	)
+	add := func(field string, weight, score float64) {
+		res.Fields[field] = score
+		res.Score += weight * score
+		total += weight
+	}
+
+	if a.Name != "" && b.Name != "" {
+		add("name", l.Weights.Name, l.enc.NameSimilarity(a.Name, b.Name))
+	}
+	if a.ParentName != "" && b.ParentName != "" {
+		add("parent_name", l.Weights.ParentName, l.enc.NameSimilarity(a.ParentName, b.ParentName))
+	}
+	if a.Village != "" && b.Village != "" {
+		add("village", l.Weights.Village, l.enc.NameSimilarity(a.Village, b.Village))
+	}
+	if !a.DOB.IsZero() && !b.DOB.IsZero() {
+		add("dob", l.Weights.DOB, dateSimilarity(a.DOB, b.DOB))
+	}
+
+	if total == 0 {
+		return res
+	}
+	res.Score /= total
+
+	switch {
+	case res.Score >= l.MatchThreshold:
+		res.Class = Match
+	case res.Score >= l.ProbableThreshold:
+		res.Class = Probable
+	}
+	return res
+}
+
+// dateSimilarity scores two dates of birth, allowing for the common
+// errors of transposed day and month, and approximate years.
+func dateSimilarity(a, b time.Time) float64 {
+	var (
+		ay, am, ad = a.Date()
+		by, bm, bd = b.Date()
+	)
+	switch {
+	case ay == by && am == bm && ad == bd:
+		return 1
+	case ay == by && int(am) == bd && ad == int(bm):
+		return 0.8
+	case ay == by && am == bm:
+		return 0.6
+	case (ay-by == 1 || by-ay == 1) && am == bm && ad == bd:
+		return 0.5
+	case ay == by:
+		return 0.4
+	}
+	return 0
+}
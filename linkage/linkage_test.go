@@ -0,0 +1,86 @@
+package linkage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cmrajan/taphone"
+)
+
+func date(y, m, d int) time.Time {
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCompare(t *testing.T) {
+	l := New(taphone.New())
+	a := Record{Name: "லட்சுமி", ParentName: "முருகன்", Village: "மேலூர்", DOB: date(1990, 3, 4)}
+
+	cases := []struct {
+		name   string
+		b      Record
+		class  Class
+		fields int
+	}{
+		{"same record", a, Match, 4},
+		{"misspelt names and swapped date", Record{Name: "லட்சுமீ", ParentName: "முருகண்", Village: "மேலூர்", DOB: date(1990, 4, 3)}, Match, 4},
+		{"loan letter spelling", Record{Name: "லக்ஷ்மி", ParentName: "முருகண்", Village: "மேலூர்", DOB: date(1990, 4, 3)}, Probable, 4},
+		{"same name only", Record{Name: "லட்சுமி", ParentName: "கண்ணன்", Village: "சேலம்", DOB: date(1985, 1, 1)}, NonMatch, 4},
+		{"different person", Record{Name: "கண்ணன்", ParentName: "ராமன்", Village: "சேலம்", DOB: date(1970, 1, 1)}, NonMatch, 4},
+		{"unknown fields are skipped", Record{Name: "லட்சுமி"}, Match, 1},
+		{"nothing to compare", Record{}, NonMatch, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res := l.Compare(a, c.b)
+			if res.Class != c.class || len(res.Fields) != c.fields {
+				t.Errorf("Compare() = %+v, want %v with %d fields", res, c.class, c.fields)
+			}
+			if res.Score < 0 || res.Score > 1 {
+				t.Errorf("Compare() score = %v, want in [0, 1]", res.Score)
+			}
+		})
+	}
+}
+
+func TestCompareThresholds(t *testing.T) {
+	l := New(taphone.New())
+	l.MatchThreshold, l.ProbableThreshold = 1.1, 0.99
+	if res := l.Compare(Record{Name: "கண்ணன்"}, Record{Name: "கண்ணன்"}); res.Class != Probable {
+		t.Errorf("Compare() class = %v with a match threshold above 1, want probable", res.Class)
+	}
+
+	l = New(taphone.New())
+	l.Weights = Weights{DOB: 1}
+	res := l.Compare(Record{Name: "கண்ணன்", DOB: date(1990, 1, 1)}, Record{Name: "ராமன்", DOB: date(1990, 1, 1)})
+	if res.Score != 1 {
+		t.Errorf("Compare() score = %v with only DOB weighted, want 1", res.Score)
+	}
+}
+
+func TestDateSimilarity(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b time.Time
+		want float64
+	}{
+		{"same", date(1990, 3, 4), date(1990, 3, 4), 1},
+		{"day and month swapped", date(1990, 3, 4), date(1990, 4, 3), 0.8},
+		{"same month", date(1990, 3, 4), date(1990, 3, 20), 0.6},
+		{"year off by one", date(1990, 3, 4), date(1991, 3, 4), 0.5},
+		{"same year", date(1990, 3, 4), date(1990, 11, 20), 0.4},
+		{"different", date(1990, 3, 4), date(1980, 3, 4), 0},
+	}
+	for _, c := range cases {
+		if got := dateSimilarity(c.a, c.b); got != c.want {
+			t.Errorf("%s: dateSimilarity() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestClassString(t *testing.T) {
+	for c, want := range map[Class]string{Match: "match", Probable: "probable", NonMatch: "non-match"} {
+		if got := c.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", c, got, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package taphone
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// lmCandidates is the number of suggestions considered per word
+	// when correcting a phrase.
+	lmCandidates = 8
+
+	// lmLambda is the weight of the bigram estimate against the
+	// unigram estimate in the interpolated model.
+	lmLambda = 0.8
+)
+
+// BigramModel is a word bigram language model used to re-rank suggestions
+// for multi-word queries by their context.
+type BigramModel struct {
+	unigrams map[string]int
+	bigrams  map[[2]string]int
+	// contexts holds the total count of bigrams starting with a word.
+	contexts map[string]int
+	total    int
+}
+
+// NewBigramModel returns an empty bigram model.
+func NewBigramModel() *BigramModel {
+	return &BigramModel{
+		unigrams: make(map[string]int),
+		bigrams:  make(map[[2]string]int),
+		contexts: make(map[string]int),
+	}
+}
+
+// LoadBigramModel reads a counts file into a new model. Each line holds
+// one or two whitespace separated words followed by a count; lines with
+// one word are unigram counts and lines with two are bigram counts.
+// Unigram counts missing from the file are derived from the bigrams.
+// Blank lines and lines starting with # are ignored.
+func LoadBigramModel(r io.Reader) (*BigramModel, error) {
+	var (
+		m   = NewBigramModel()
+		sc  = bufio.NewScanner(r)
+		n   = 0
+		uni = false
+	)
+	for sc.Scan() {
+		n++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		f := strings.Fields(line)
+		if len(f) < 2 || len(f) > 3 {
+			return nil, fmt.Errorf("line %d: expected one or two words and a count", n)
+		}
+		c, err := strconv.Atoi(f[len(f)-1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid count %q", n, f[len(f)-1])
+		}
+
+		if len(f) == 2 {
+			m.unigrams[f[0]] += c
+			m.total += c
+			uni = true
+			continue
+		}
+		m.bigrams[[2]string{f[0], f[1]}] += c
+		m.contexts[f[0]] += c
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if !uni {
+		for b, c := range m.bigrams {
+			m.unigrams[b[1]] += c
+			m.total += c
+		}
+	}
+	return m, nil
+}
+
+// Add records an occurrence of the given word sequence in the model.
+func (m *BigramModel) Add(words ...string) {
+	for i, w := range words {
+		m.unigrams[w]++
+		m.total++
+		if i > 0 {
+			m.bigrams[[2]string{words[i-1], w}]++
+			m.contexts[words[i-1]]++
+		}
+	}
+}
+
+// logProb returns the log probability of w following prev. An empty prev
+// denotes the start of the phrase and yields the unigram estimate.
+func (m *BigramModel) logProb(prev, w string) float64 {
+	// Add-one smoothed unigram estimate.
+	uni := float64(m.unigrams[w]+1) / float64(m.total+len(m.unigrams)+1)
+	if prev == "" || m.contexts[prev] == 0 {
+		return math.Log(uni)
+	}
+
+	bi := float64(m.bigrams[[2]string{prev, w}]) / float64(m.contexts[prev])
+	return math.Log(lmLambda*bi + (1-lmLambda)*uni)
+}
+
+// CorrectPhrase corrects each word of a whitespace separated phrase to
+// the dictionary suggestion that best fits its context. Per-word
+// suggestions are re-ranked with the bigram model and the most likely
+// sequence is returned. If lm is nil, each word is corrected to its best
+// suggestion independently. Words with no suggestions are left as is.
+func (d *Dictionary) CorrectPhrase(phrase string, lm *BigramModel) []string {
+	words := strings.Fields(phrase)
+	if len(words) == 0 {
+		return nil
+	}
+
+	type node struct {
+		word  string
+		score float64
+		back  int
+	}
+
+	// Viterbi over the candidate lattice.
+	var lattice [][]node
+	for i, w := range words {
+		var cands []node
+		for _, s := range d.Suggest(w, lmCandidates) {
+			cands = append(cands, node{word: s.Word, score: math.Log(math.Max(s.Score, 1e-6))})
+		}
+		if len(cands) == 0 {
+			cands = []node{{word: w}}
+		}
+
+		for j := range cands {
+			c := &cands[j]
+			if i == 0 {
+				if lm != nil {
+					c.score += lm.logProb("", c.word)
+				}
+				continue
+			}
+
+			best := math.Inf(-1)
+			for k, p := range lattice[i-1] {
+				s := p.score
+				if lm != nil {
+					s += lm.logProb(p.word, c.word)
+				}
+				if s > best {
+					best, c.back = s, k
+				}
+			}
+			c.score += best
+		}
+		lattice = append(lattice, cands)
+	}
+
+	// Trace back the best path.
+	var (
+		last = lattice[len(lattice)-1]
+		b    = 0
+	)
+	for k := range last {
+		if last[k].score > last[b].score {
+			b = k
+		}
+	}
+	out := make([]string, len(words))
+	for i := len(lattice) - 1; i >= 0; i-- {
+		out[i] = lattice[i][b].word
+		b = lattice[i][b].back
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package taphone
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCorrectPhrase(t *testing.T) {
+	d := testDictionary()
+	d.Add("ஊர்ந்தது", 3)
+	d.Add("சென்றேன்", 3)
+
+	lm := NewBigramModel()
+	lm.Add("பல்லி", "ஊர்ந்தது")
+	lm.Add("பல்லி", "ஊர்ந்தது")
+	lm.Add("பள்ளி", "சென்றேன்")
+
+	cases := []struct {
+		name   string
+		phrase string
+		lm     *BigramModel
+		want   []string
+	}{
+		{"without a model", "பள்ளீ ஊர்ந்தது", nil, []string{"பள்ளி", "ஊர்ந்தது"}},
+		{"context picks the word", "பள்ளீ ஊர்ந்தது", lm, []string{"பல்லி", "ஊர்ந்தது"}},
+		{"context keeps the word", "பள்ளீ சென்றேன்", lm, []string{"பள்ளி", "சென்றேன்"}},
+		{"misspelt words", "வனக்கம் மளை", lm, []string{"வணக்கம்", "மலை"}},
+		{"unknown words are kept", "hello மளை", lm, []string{"hello", "மலை"}},
+		{"empty", "  ", lm, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := d.CorrectPhrase(c.phrase, c.lm); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("CorrectPhrase(%q) = %q, want %q", c.phrase, got, c.want)
+			}
+		})
+	}
+}
+
+func TestLoadBigramModel(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		uni   map[string]int
+		total int
+		err   string
+	}{
+		{"unigrams and bigrams", "# counts\nபல்லி 4\nஊர்ந்தது 2\nபல்லி ஊர்ந்தது 2\n",
+			map[string]int{"பல்லி": 4, "ஊர்ந்தது": 2}, 6, ""},
+		{"unigrams derived from bigrams", "பல்லி ஊர்ந்தது 2\nபள்ளி சென்றேன் 1\n",
+			map[string]int{"ஊர்ந்தது": 2, "சென்றேன்": 1}, 3, ""},
+		{"invalid count", "பல்லி many\n", nil, 0, `line 1: invalid count "many"`},
+		{"too many words", "\nஒரு இரு மூன்று 1\n", nil, 0, "line 2: expected one or two words"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m, err := LoadBigramModel(strings.NewReader(c.input))
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Errorf("LoadBigramModel() error = %v, want %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(m.unigrams, c.uni) || m.total != c.total {
+				t.Errorf("unigrams = %v (%d), want %v (%d)", m.unigrams, m.total, c.uni, c.total)
+			}
+		})
+	}
+}
+
+func TestBigramModelLogProb(t *testing.T) {
+	lm := NewBigramModel()
+	lm.Add("பல்லி", "ஊர்ந்தது")
+	lm.Add("பள்ளி", "சென்றேன்")
+
+	if lm.logProb("பல்லி", "ஊர்ந்தது") <= lm.logProb("பல்லி", "சென்றேன்") {
+		t.Error("a seen bigram is no more likely than an unseen one")
+	}
+	if lm.logProb("", "ஊர்ந்தது") != lm.logProb("அது", "ஊர்ந்தது") {
+		t.Error("an unknown context doesn't fall back to the unigram estimate")
+	}
+}
@@ -0,0 +1,67 @@
+package taphone
+
+// loanLetters maps the Grantha letters, and the aytham digraphs that
+// write the loan sounds f and z, to their codes, in the order they are
+// replaced. The codes are letters that no other code uses, so that they
+// can't be read as part of another code or joined into one: ஜ is coded D
+// as NJ is ஞ, ஷ X as SH would read as ஸ and ஹ, and z Q as Z is ழ.
+var loanLetters = [][2]string{
+	{"ஃப", "F"}, {"ஃஜ", "Q"},
+	{"ஜ", "D"}, {"ஷ", "X"}, {"ஸ", "S"}, {"ஶ", "S"}, {"ஹ", "H"},
+}
+
+// loanFolds maps the codes of foreign sounds to the codes of the nearest
+// sounds that they fold to with WithLoanFolding.
+var loanFolds = map[string]string{"F": "P", "Q": "D", "X": "S"}
+
+// romanLoanConsonants are the Latin consonant spellings of loan sounds,
+// used by EncodeRoman in place of those in romanConsonants when loan
+// sounds are coded.
+var romanLoanConsonants = func() map[string]string {
+	out := copyMap(romanConsonants)
+	for s, c := range map[string]string{"j": "D", "sh": "X", "h": "H", "f": "F"} {
+		out[s] = c
+	}
+	return out
+}()
+
+// WithLoanSounds codes the loan sounds that the default tables drop:
+// the Grantha letters ஜ (D), ஷ (X), ஸ and ஶ (S) and ஹ (H), and f and z
+// written with the aytham, ஃப (F) and ஃஜ (Q). The codes are kept at every
+// key level, so that loanwords key faithfully (ஃபேன் and பேன் differ).
+func WithLoanSounds() Option {
+	return func(k *TAphone) {
+		k.loanSounds = true
+	}
+}
+
+// WithLoanFolding codes loan sounds like WithLoanSounds, but folds the
+// foreign sounds F, Q and X into the nearest sounds P, D and S in key0
+// and key1, trading faithfulness for recall: key2 tells ஃபேன் from பேன்
+// and key0 and key1 match them.
+func WithLoanFolding() Option {
+	return func(k *TAphone) {
+		k.loanSounds = true
+		k.loanFold = true
+	}
+}
+
+// foldLoanCodes folds the codes of foreign sounds in a key. Keys that
+// can't be parsed are returned as is.
+func foldLoanCodes(key string) string {
+	toks, ok := tokenizeKey(key)
+	if !ok {
+		return key
+	}
+
+	var b []byte
+	for _, t := range toks {
+		c := t.code
+		if f, ok := loanFolds[c]; ok {
+			c = f
+		}
+		b = append(b, c...)
+		b = append(b, t.mods...)
+	}
+	return string(b)
+}
@@ -0,0 +1,42 @@
+package taphone
+
+import "testing"
+
+func TestLoanSounds(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  []Option
+		input string
+		keys  [3]string
+	}{
+		{"default drops loan letters", nil, "ஜெயா", [3]string{"Y", "Y", "5Y"}},
+		{"loan sounds", []Option{WithLoanSounds()}, "ஜெயா", [3]string{"DY", "DY", "D5Y"}},
+		{"aytham f", []Option{WithLoanSounds()}, "ஃபேன்", [3]string{"FN", "FN1", "F5N1"}},
+		{"ஜ after ந is not ஞ", []Option{WithLoanSounds()}, "நஜீர்", [3]string{"ND3R", "ND3R", "ND3R"}},
+		{"ஸ and ஹ are not ஷ", []Option{WithLoanSounds()}, "ஸஹானா", [3]string{"SHN", "SHN1", "SHN1"}},
+		{"folding keeps ஸஹ", []Option{WithLoanFolding()}, "ஸஹானா", [3]string{"SHN", "SHN1", "SHN1"}},
+		{"folding folds ஷ", []Option{WithLoanFolding()}, "ஷானா", [3]string{"SN", "SN1", "XN1"}},
+		{"folding folds f", []Option{WithLoanFolding()}, "ஃபேன்", [3]string{"PN", "PN1", "F5N1"}},
+		{"folding folds z", []Option{WithLoanFolding()}, "ஃஜூ", [3]string{"D", "D", "Q4"}},
+		{"max length counts loan codes", []Option{WithLoanSounds(), WithMaxLength(2)}, "ஸஹானா", [3]string{"SH", "SH", "SH"}},
+		{"verbose", []Option{WithLoanSounds(), WithVerboseCodes()}, "ஷஹஜா", [3]string{"SH-H-J", "SH-H-J", "SH-H-J"}},
+		{"verbose z", []Option{WithLoanSounds(), WithVerboseCodes()}, "ஃஜூஸ்", [3]string{"Z-S", "Z-S", "Zu-S"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k0, k1, k2 := New(c.opts...).Encode(c.input)
+			if got := [3]string{k0, k1, k2}; got != c.keys {
+				t.Errorf("Encode(%q) = %q, want %q", c.input, got, c.keys)
+			}
+		})
+	}
+}
+
+func TestLoanCodesTokenize(t *testing.T) {
+	for _, l := range loanLetters {
+		toks, ok := tokenizeKey("N" + l[1] + "A")
+		if !ok || len(toks) != 3 || toks[1].code != l[1] {
+			t.Errorf("tokenizeKey(%q) = %v, %v, want N %s A", "N"+l[1]+"A", toks, ok, l[1])
+		}
+	}
+}
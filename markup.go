@@ -0,0 +1,45 @@
+package taphone
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	regexMarkupBlocks = regexp.MustCompile(`(?is)<!--.*?-->|<(script|style)\b[^>]*>.*?</(script|style)\s*>`)
+	regexMarkupTags   = regexp.MustCompile(`(?s)</?([a-zA-Z!][a-zA-Z0-9]*)[^>]*>`)
+)
+
+// inlineTags are HTML elements that may appear inside a word. They are
+// removed without leaving a space.
+var inlineTags = map[string]bool{
+	"a": true, "abbr": true, "b": true, "em": true, "font": true, "i": true,
+	"mark": true, "s": true, "small": true, "span": true, "strong": true,
+	"sub": true, "sup": true, "u": true, "wbr": true,
+}
+
+// StripMarkup removes HTML and XML markup from s: comments, scripts and
+// style sheets are removed with their contents, inline tags (<b>, <span>)
+// are removed, other tags are replaced with spaces so that the text on
+// either side doesn't run together, and character entities (&amp;,
+// &#2965;) are decoded.
+func StripMarkup(s string) string {
+	s = regexMarkupBlocks.ReplaceAllString(s, " ")
+	s = regexMarkupTags.ReplaceAllStringFunc(s, func(t string) string {
+		m := regexMarkupTags.FindStringSubmatch(t)
+		if inlineTags[strings.ToLower(m[1])] {
+			return ""
+		}
+		return " "
+	})
+	return html.UnescapeString(s)
+}
+
+// WithMarkupStripping removes markup from the input with StripMarkup
+// before it is encoded, for text scraped from web pages.
+func WithMarkupStripping() Option {
+	return func(k *TAphone) {
+		k.addFilter("markup", StripMarkup)
+	}
+}
@@ -0,0 +1,35 @@
+package taphone
+
+import "testing"
+
+func TestStripMarkup(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"inline tags", "வண<b>க்க</b>ம்", "வணக்கம்"},
+		{"inline tag case", "வண<SPAN class=x>க்கம்</SPAN>", "வணக்கம்"},
+		{"block tags", "<p>வணக்கம்</p><p>நண்பர்</p>", " வணக்கம்  நண்பர் "},
+		{"line break", "வணக்கம்<br/>நண்பர்", "வணக்கம் நண்பர்"},
+		{"comment", "வண<!-- x -->க்கம்", "வண க்கம்"},
+		{"script", "<script type=\"text/javascript\">var a = '<b>';</script>மரம்", " மரம்"},
+		{"style", "<STYLE>p { color: red }</style>மரம்", " மரம்"},
+		{"entities", "&#2965;&#3021;&amp;&lt;", "க்&<"},
+		{"no markup", "a < b", "a < b"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := StripMarkup(c.input); got != c.want {
+				t.Errorf("StripMarkup(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithMarkupStripping(t *testing.T) {
+	k := New(WithMarkupStripping())
+	if got, want := k.EncodeLevel("<p>வண<b>க்க</b>ம்</p>", Key2), New().EncodeLevel("வணக்கம்", Key2); got != want {
+		t.Errorf("key2 = %q, want %q", got, want)
+	}
+}
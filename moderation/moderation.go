@@ -0,0 +1,157 @@
+// Package moderation matches obfuscated Tamil words against a blocklist.
+// Users evade word filters by inserting dots, spaces, symbols or emoji
+// between letters and by repeating letters. The matcher rejoins such
+// split words, normalises them with taphone.Deobfuscate, collapses
+// repeated letters and compares their taphone keys with the keys of the
+// blocklisted words at a chosen key level, so that misspelt and
+// disguised variants match as well.
+package moderation
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cmrajan/taphone"
+)
+
+// DefaultMaxSpan is the default maximum number of tokens that are joined
+// to rejoin a split word.
+const DefaultMaxSpan = 8
+
+// Hit is a blocklisted word found in a text.
+type Hit struct {
+	// Word is the blocklisted word that matched.
+	Word string
+
+	// Text is the matched text as it appears in the input, and Start and
+	// End are its byte offsets.
+	Text  string
+	Start int
+	End   int
+}
+
+// Matcher finds blocklisted words in text.
+type Matcher struct {
+	enc     *taphone.TAphone
+	level   taphone.KeyLevel
+	blocked map[string]string
+
+	// MaxSpan is the maximum number of tokens joined to rejoin a word.
+	MaxSpan int
+}
+
+// New returns a matcher for the given blocklist that compares keys at
+// the given level. Lower levels catch more variants at the cost of more
+// false positives.
+func New(enc *taphone.TAphone, level taphone.KeyLevel, blocklist []string) *Matcher {
+	m := &Matcher{
+		enc:     enc,
+		level:   level,
+		blocked: make(map[string]string),
+		MaxSpan: DefaultMaxSpan,
+	}
+	for _, w := range blocklist {
+		if key := m.key(w); key != "" {
+			m.blocked[key] = w
+		}
+	}
+	return m
+}
+
+// Match reports whether text contains a blocklisted word.
+func (m *Matcher) Match(text string) bool {
+	return len(m.Find(text)) > 0
+}
+
+// Find returns the non-overlapping blocklisted words found in text, in
+// order. Longer matches are preferred.
+func (m *Matcher) Find(text string) []Hit {
+	var (
+		toks = taphone.Tokenize(text)
+		out  []Hit
+	)
+	for i := 0; i < len(toks); i++ {
+		if toks[i].Kind != taphone.TokenTamil {
+			continue
+		}
+
+		// Extend a window of joinable tokens from i and remember the
+		// longest one that matches.
+		var (
+			buf   strings.Builder
+			hit   *Hit
+			prev  = -1
+			count = 0
+		)
+		for j := i; j < len(toks) && count < m.MaxSpan; j++ {
+			t := toks[j]
+			if t.Kind == taphone.TokenLatin || t.Kind == taphone.TokenNumber || t.Kind == taphone.TokenOther {
+				break
+			}
+			if t.Kind == taphone.TokenPunct {
+				count++
+				continue
+			}
+			if prev > -1 && !joinable(text, toks[prev], t) {
+				break
+			}
+
+			buf.WriteString(t.Text)
+			prev = j
+			count++
+
+			if w, ok := m.blocked[m.key(buf.String())]; ok {
+				hit = &Hit{Word: w, Start: toks[i].Start, End: t.End}
+			} else if j == i {
+				if w, ok := m.blocked[m.key(taphone.Stem(t.Text))]; ok {
+					hit = &Hit{Word: w, Start: t.Start, End: t.End}
+				}
+			}
+		}
+
+		if hit != nil {
+			hit.Text = text[hit.Start:hit.End]
+			out = append(out, *hit)
+
+			// Skip the tokens covered by the hit.
+			for i+1 < len(toks) && toks[i+1].Start < hit.End {
+				i++
+			}
+		}
+	}
+	return out
+}
+
+// joinable reports whether the Tamil tokens a and b, in that order, may
+// be parts of one split word. Tokens separated only by punctuation or
+// symbols are joinable. Tokens separated by whitespace are joinable only
+// if both are short, as with words spelt out letter by letter.
+func joinable(text string, a, b taphone.Token) bool {
+	if strings.IndexFunc(text[a.End:b.Start], unicode.IsSpace) < 0 {
+		return true
+	}
+	return len(taphone.Graphemes(a.Text)) <= 2 && len(taphone.Graphemes(b.Text)) <= 2
+}
+
+// key normalises and keys s at the matcher's level.
+func (m *Matcher) key(s string) string {
+	return m.enc.EncodeLevel(collapse(taphone.Deobfuscate(s)), m.level)
+}
+
+// collapse collapses runs of identical graphemes in s to one.
+// taphone.Deobfuscate only collapses longer runs, as doubled graphemes
+// occur in ordinary words, but a doubled letter is enough to evade a
+// blocklist.
+func collapse(s string) string {
+	var (
+		out  strings.Builder
+		prev string
+	)
+	for _, g := range taphone.Graphemes(s) {
+		if g != prev {
+			out.WriteString(g)
+		}
+		prev = g
+	}
+	return out.String()
+}
@@ -0,0 +1,68 @@
+package moderation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+func TestFind(t *testing.T) {
+	m := New(taphone.New(), taphone.Key1, []string{"முட்டாள்", "பூனை"})
+
+	cases := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"plain word", "நீ ஒரு முட்டாள்", []string{"முட்டாள்"}},
+		{"dots between letters", "மு.ட்.டா.ள்", []string{"மு.ட்.டா.ள்"}},
+		{"spelt out", "மு ட் டா ள்", []string{"மு ட் டா ள்"}},
+		{"emoji inside", "பூ😀னை வந்தது", []string{"பூ😀னை"}},
+		{"repeated letters", "பூபூனை", []string{"பூபூனை"}},
+		{"inflected", "முட்டாள்கள்", []string{"முட்டாள்கள்"}},
+		{"several", "மு-ட்-டா-ள் பூனை", []string{"மு-ட்-டா-ள்", "பூனை"}},
+		{"similar word", "முட்டை", nil},
+		{"long words aren't joined", "முட்டு ஆள்", nil},
+		{"latin", "hello", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			for _, h := range m.Find(c.text) {
+				got = append(got, h.Text)
+				if c.text[h.Start:h.End] != h.Text {
+					t.Errorf("hit %+v doesn't match its offsets", h)
+				}
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Find(%q) = %q, want %q", c.text, got, c.want)
+			}
+			if m.Match(c.text) != (len(c.want) > 0) {
+				t.Errorf("Match(%q) = %v", c.text, !(len(c.want) > 0))
+			}
+		})
+	}
+}
+
+func TestMaxSpan(t *testing.T) {
+	m := New(taphone.New(), taphone.Key1, []string{"முட்டாள்"})
+	m.MaxSpan = 3
+	if m.Match("மு.ட்.டா.ள்") {
+		t.Error("Match() joined more tokens than MaxSpan")
+	}
+}
+
+func TestCollapse(t *testing.T) {
+	cases := map[string]string{
+		"பூபூனை":   "பூனை",
+		"முட்டாள்": "முட்டாள்",
+		"கககக":     "க",
+		"":         "",
+	}
+	for in, want := range cases {
+		if got := collapse(in); got != want {
+			t.Errorf("collapse(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package taphone
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Namespaces is a set of isolated, named dictionaries, so that one
+// service can host phonetic search for many tenants. Each namespace has
+// its own words and its own encoder, configured with its own options
+// (profiles, expansions, stopwords). It is safe for concurrent use.
+type Namespaces struct {
+	mu sync.RWMutex
+	ns map[string]*Dictionary
+}
+
+// NewNamespaces returns an empty set of namespaces.
+func NewNamespaces() *Namespaces {
+	return &Namespaces{ns: make(map[string]*Dictionary)}
+}
+
+// Create creates an empty namespace whose dictionary keys words with an
+// encoder configured with opts, and returns the dictionary. Names are
+// made of letters, digits, '-', '_' and '.'.
+func (n *Namespaces) Create(name string, opts ...Option) (*Dictionary, error) {
+	if !isNamespaceName(name) {
+		return nil, fmt.Errorf("invalid namespace name %q", name)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.ns[name]; ok {
+		return nil, fmt.Errorf("namespace %s exists", name)
+	}
+
+	d := NewDictionary(New(opts...))
+	n.ns[name] = d
+	return d, nil
+}
+
+// Get returns the dictionary of a namespace.
+func (n *Namespaces) Get(name string) (*Dictionary, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	d, ok := n.ns[name]
+	return d, ok
+}
+
+// Delete deletes a namespace and reports whether it existed.
+func (n *Namespaces) Delete(name string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, ok := n.ns[name]
+	delete(n.ns, name)
+	return ok
+}
+
+// Names returns the names of the namespaces in sorted order.
+func (n *Namespaces) Names() []string {
+	n.mu.RLock()
+	out := make([]string, 0, len(n.ns))
+	for name := range n.ns {
+		out = append(out, name)
+	}
+	n.mu.RUnlock()
+	sort.Strings(out)
+	return out
+}
+
+// Snapshot writes the words of a namespace to w with Dictionary.Save.
+// Options aren't part of a snapshot and are given again to Restore.
+func (n *Namespaces) Snapshot(name string, w io.Writer) error {
+	d, ok := n.Get(name)
+	if !ok {
+		return fmt.Errorf("unknown namespace %s", name)
+	}
+	if _, err := fmt.Fprintf(w, "# taphone namespace %s\n", name); err != nil {
+		return err
+	}
+	return d.Save(w)
+}
+
+// Restore replaces the namespace name, or creates it, with a dictionary
+// loaded from a snapshot and keyed with an encoder configured with opts.
+// The snapshot is loaded in full before it replaces the namespace, so
+// that searches never see a partly restored namespace, and the namespace
+// is left unchanged if loading fails.
+func (n *Namespaces) Restore(name string, r io.Reader, opts ...Option) error {
+	if !isNamespaceName(name) {
+		return fmt.Errorf("invalid namespace name %q", name)
+	}
+
+	d := NewDictionary(New(opts...))
+	if err := d.Load(r); err != nil {
+		return fmt.Errorf("error restoring namespace %s: %v", name, err)
+	}
+
+	n.mu.Lock()
+	n.ns[name] = d
+	n.mu.Unlock()
+	return nil
+}
+
+// Rules returns the effective configuration of a namespace's encoder.
+func (n *Namespaces) Rules(name string) (Rules, bool) {
+	d, ok := n.Get(name)
+	if !ok {
+		return Rules{}, false
+	}
+	return d.enc.Rules(), true
+}
+
+// isNamespaceName reports whether s is a valid namespace name.
+func isNamespaceName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
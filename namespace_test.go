@@ -0,0 +1,111 @@
+package taphone
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNamespaces(t *testing.T) {
+	n := NewNamespaces()
+	for _, name := range []string{"b", "a.1", "tenant_2-x"} {
+		if _, err := n.Create(name); err != nil {
+			t.Fatalf("Create(%q): %v", name, err)
+		}
+	}
+
+	cases := []struct {
+		name string
+		ok   bool
+	}{
+		{"c", true},
+		{"b", false},
+		{"", false},
+		{"a/b", false},
+		{"தமிழ்", false},
+	}
+	for _, c := range cases {
+		if _, err := n.Create(c.name); (err == nil) != c.ok {
+			t.Errorf("Create(%q) error = %v, want ok %v", c.name, err, c.ok)
+		}
+	}
+
+	if got, want := n.Names(), []string{"a.1", "b", "c", "tenant_2-x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %q, want %q", got, want)
+	}
+	if !n.Delete("c") || n.Delete("c") {
+		t.Error("Delete(c) didn't report the namespace once")
+	}
+	if _, ok := n.Get("c"); ok {
+		t.Error("Get(c) found a deleted namespace")
+	}
+}
+
+func TestNamespacesIsolation(t *testing.T) {
+	n := NewNamespaces()
+	a, _ := n.Create("a")
+	b, _ := n.Create("b", WithLoanSounds())
+	a.Add("கடல்", 1)
+
+	if b.Contains("கடல்") {
+		t.Error("a word added to a is in b")
+	}
+	if r, _ := n.Rules("b"); !r.LoanSounds {
+		t.Error("Rules(b) lost the namespace's options")
+	}
+	if r, _ := n.Rules("a"); r.LoanSounds {
+		t.Error("Rules(a) has the options of b")
+	}
+	if _, ok := n.Rules("c"); ok {
+		t.Error("Rules(c) found an unknown namespace")
+	}
+}
+
+func TestNamespacesSnapshot(t *testing.T) {
+	n := NewNamespaces()
+	d, _ := n.Create("a")
+	d.Add("கடல்", 3)
+	d.Add("மழை", 1)
+
+	var buf bytes.Buffer
+	if err := n.Snapshot("a", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Snapshot("b", &buf); err == nil {
+		t.Error("Snapshot(b) of an unknown namespace succeeded")
+	}
+
+	cases := []struct {
+		name     string
+		snapshot string
+		ok       bool
+	}{
+		{"snapshot", buf.String(), true},
+		{"long comment line", "# " + strings.Repeat("x", 200*1024) + "\n" + buf.String(), true},
+		{"invalid frequency", "கடல் many\n", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			n.Create("r")
+			n.ns["r"].Add("பழைய", 1)
+
+			err := n.Restore("r", strings.NewReader(c.snapshot))
+			if (err == nil) != c.ok {
+				t.Fatalf("Restore() error = %v, want ok %v", err, c.ok)
+			}
+			r, _ := n.Get("r")
+			if c.ok && (r.Freq("கடல்") != 3 || r.Freq("மழை") != 1 || r.Contains("பழைய")) {
+				t.Errorf("restored namespace has %d words, கடல் %d, மழை %d", r.Len(), r.Freq("கடல்"), r.Freq("மழை"))
+			}
+			if !c.ok && !r.Contains("பழைய") {
+				t.Error("a failed Restore changed the namespace")
+			}
+			n.Delete("r")
+		})
+	}
+
+	if err := n.Restore("a/b", strings.NewReader("")); err == nil {
+		t.Error("Restore() to an invalid name succeeded")
+	}
+}
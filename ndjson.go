@@ -0,0 +1,72 @@
+package taphone
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// EncodeNDJSON copies newline delimited JSON records from r to w, adding
+// the three phrase keys (EncodePhrase) of the string at each of the given
+// paths to every record. A path is a dot separated list of object fields
+// (author.name) and its keys are added to the object that holds the
+// field as <field>_key0, <field>_key1 and <field>_key2. Records that lack
+// a path, or have a value that isn't a string at it, are copied without
+// its keys. Fields of the records written are sorted by name.
+func (k *TAphone) EncodeNDJSON(r io.Reader, w io.Writer, paths ...string) error {
+	if len(paths) == 0 {
+		return errors.New("no paths to encode")
+	}
+	ps := make([][]string, len(paths))
+	for i, p := range paths {
+		ps[i] = strings.Split(p, ".")
+	}
+
+	p, r := k.newProgress(r)
+	var (
+		dec = json.NewDecoder(r)
+		enc = json.NewEncoder(w)
+	)
+	dec.UseNumber()
+	enc.SetEscapeHTML(false)
+
+	for n := 1; ; n++ {
+		var rec map[string]interface{}
+		if err := dec.Decode(&rec); err == io.EOF {
+			p.finish()
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error reading record %d: %v", n, err)
+		}
+
+		for _, p := range ps {
+			k.encodePath(rec, p)
+		}
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+		p.add(1)
+	}
+}
+
+// encodePath adds the keys of the string at path in obj to the object
+// that holds it.
+func (k *TAphone) encodePath(obj map[string]interface{}, path []string) {
+	for _, f := range path[:len(path)-1] {
+		o, ok := obj[f].(map[string]interface{})
+		if !ok {
+			return
+		}
+		obj = o
+	}
+
+	f := path[len(path)-1]
+	s, ok := obj[f].(string)
+	if !ok {
+		return
+	}
+	k0, k1, k2 := k.EncodePhrase(s)
+	obj[f+"_key0"], obj[f+"_key1"], obj[f+"_key2"] = k0, k1, k2
+}
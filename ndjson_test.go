@@ -0,0 +1,63 @@
+package taphone
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncodeNDJSON(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		paths []string
+		want  string
+		err   string
+	}{
+		{
+			name:  "top level field",
+			input: `{"name":"முருகன்","id":12345678901234567890}` + "\n",
+			paths: []string{"name"},
+			want:  `{"id":12345678901234567890,"name":"முருகன்","name_key0":"MRKN","name_key1":"MRKN1","name_key2":"M4R4KN1"}` + "\n",
+		},
+		{
+			name:  "nested field",
+			input: `{"author":{"name":"மரம் <b>"}}`,
+			paths: []string{"author.name"},
+			want:  `{"author":{"name":"மரம் <b>","name_key0":"MRM","name_key1":"MRM","name_key2":"MRM"}}` + "\n",
+		},
+		{
+			name:  "missing and non-string values",
+			input: `{"name":1}` + "\n" + `{"author":"x"}` + "\n" + `{}`,
+			paths: []string{"name", "author.name"},
+			want:  `{"name":1}` + "\n" + `{"author":"x"}` + "\n" + `{}` + "\n",
+		},
+		{
+			name:  "several records",
+			input: `{"a":"கல்"} {"a":"மரம்"}`,
+			paths: []string{"a"},
+			want: `{"a":"கல்","a_key0":"KL","a_key1":"KL","a_key2":"KL"}` + "\n" +
+				`{"a":"மரம்","a_key0":"MRM","a_key1":"MRM","a_key2":"MRM"}` + "\n",
+		},
+		{name: "no paths", input: `{}`, err: "no paths"},
+		{name: "bad record", input: `{"a":"கல்"}` + "\n" + `{"a":`, paths: []string{"a"}, err: "error reading record 2"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := New().EncodeNDJSON(strings.NewReader(c.input), &out, c.paths...)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Errorf("EncodeNDJSON() error = %v, want %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != c.want {
+				t.Errorf("EncodeNDJSON() = %s, want %s", out.String(), c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package taphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WithNgramFallback gives inputs whose keys are empty or a single code
+// (symbols, numbers, Latin words and very short Tamil fragments) a
+// fallback key of their character n-grams, so that every token has
+// something to index. The fallback, the lowercased n-grams of graphemes
+// of the input each prefixed by a #, is appended to all three keys
+// (₹ → #₹, abc → #ab#bc, பூ → P#பூ). Fallback keys only match identical
+// text. The default analyzer then keeps all tokens but
+// punctuation. n < 1 disables the fallback.
+func WithNgramFallback(n int) Option {
+	return func(k *TAphone) {
+		k.ngram = n
+	}
+}
+
+// ngramFallback returns the fallback key of an input with the given key0,
+// or "" if key0 has more than one code.
+func (k *TAphone) ngramFallback(input, key0 string) string {
+	if t, ok := tokenizeKey(key0); ok && len(t) > 1 {
+		return ""
+	}
+
+	g := Graphemes(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, input))
+	if len(g) == 0 {
+		return ""
+	}
+
+	n := k.ngram
+	if len(g) < n {
+		n = len(g)
+	}
+	var b strings.Builder
+	for i := 0; i+n <= len(g); i++ {
+		b.WriteByte('#')
+		b.WriteString(strings.Join(g[i:i+n], ""))
+	}
+	return b.String()
+}
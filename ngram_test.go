@@ -0,0 +1,42 @@
+package taphone
+
+import "testing"
+
+func TestWithNgramFallback(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  []Option
+		input string
+		keys  [3]string
+	}{
+		{"symbol", []Option{WithNgramFallback(2)}, "₹", [3]string{"#₹", "#₹", "#₹"}},
+		{"number", []Option{WithNgramFallback(2)}, "12", [3]string{"#12", "#12", "#12"}},
+		{"latin word", []Option{WithNgramFallback(2)}, "abc", [3]string{"#ab#bc", "#ab#bc", "#ab#bc"}},
+		{"latin case", []Option{WithNgramFallback(2)}, "ABC", [3]string{"#ab#bc", "#ab#bc", "#ab#bc"}},
+		{"whitespace", []Option{WithNgramFallback(2)}, "a b", [3]string{"#ab", "#ab", "#ab"}},
+		{"single code", []Option{WithNgramFallback(2)}, "பூ", [3]string{"P#பூ", "P#பூ", "P4#பூ"}},
+		{"tamil word", []Option{WithNgramFallback(2)}, "வணக்கம்", [3]string{"VNKKM", "VNKKM", "VNKKM"}},
+		{"trigrams", []Option{WithNgramFallback(3)}, "abcd", [3]string{"#abc#bcd", "#abc#bcd", "#abc#bcd"}},
+		{"disabled", []Option{WithNgramFallback(0)}, "abc", [3]string{}},
+		{"empty", []Option{WithNgramFallback(2)}, "", [3]string{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got [3]string
+			got[0], got[1], got[2] = New(c.opts...).Encode(c.input)
+			if got != c.keys {
+				t.Errorf("Encode(%q) = %q, want %q", c.input, got, c.keys)
+			}
+		})
+	}
+}
+
+func TestNgramFallbackPhrase(t *testing.T) {
+	k := New(WithNgramFallback(2))
+	if got, _, _ := k.EncodePhrase("வணக்கம் ₹ 100, abc!"); got != "VNKKM #₹ #10#00 #ab#bc" {
+		t.Errorf("EncodePhrase() = %q, want %q", got, "VNKKM #₹ #10#00 #ab#bc")
+	}
+	if got, want := k.EncodeRoman("vanakkam"), New().EncodeRoman("vanakkam"); got != want {
+		t.Errorf("EncodeRoman() = %q, want %q without a fallback", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package taphone
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// numeralWords are the Tamil word forms of 0 to 19, and tens are those of
+// 20 to 90. tensCombining are the forms of the tens that units are added
+// to (இருபத்து + ஒன்று → இருபத்தொன்று).
+var (
+	numeralWords = []string{
+		"பூஜ்ஜியம்", "ஒன்று", "இரண்டு", "மூன்று", "நான்கு", "ஐந்து", "ஆறு", "ஏழு",
+		"எட்டு", "ஒன்பது", "பத்து", "பதினொன்று", "பன்னிரண்டு", "பதின்மூன்று",
+		"பதினான்கு", "பதினைந்து", "பதினாறு", "பதினேழு", "பதினெட்டு", "பத்தொன்பது",
+	}
+	tens = []string{
+		"", "", "இருபது", "முப்பது", "நாற்பது", "ஐம்பது", "அறுபது", "எழுபது",
+		"எண்பது", "தொண்ணூறு",
+	}
+	tensCombining = []string{
+		"", "", "இருபத்து", "முப்பத்து", "நாற்பத்து", "ஐம்பத்து", "அறுபத்து",
+		"எழுபத்து", "எண்பத்து", "தொண்ணூற்று",
+	}
+)
+
+// numeralSuffixes are the spellings of suffixes commonly written after
+// numerals, mapped to their vowel initial forms (2வது → இரண்டு + ஆவது).
+var numeralSuffixes = map[string]string{
+	"வது": "ஆவது", "ம்": "ஆம்", "ல்": "இல்", "ஆம்": "ஆம்", "ஆவது": "ஆவது",
+}
+
+// vowelSigns maps vowels to their vowel signs.
+var vowelSigns = map[rune]string{
+	'அ': "", 'ஆ': "ா", 'இ': "ி", 'ஈ': "ீ", 'உ': "ு", 'ஊ': "ூ", 'எ': "ெ",
+	'ஏ': "ே", 'ஐ': "ை", 'ஒ': "ொ", 'ஓ': "ோ", 'ஔ': "ௌ",
+}
+
+// regexNumeralWord matches numerals attached to Tamil letters, with the
+// letters that follow them.
+var regexNumeralWord = regexp.MustCompile(`([0-9௦-௯]+)([\x{0b82}-\x{0bd7}]+)`)
+
+// NormalizeNumerals replaces numerals from 0 to 99 (Arabic or Tamil
+// digits) that are written attached to a Tamil suffix with their Tamil
+// word forms, joining the suffix by the rules of sandhi (2வது → இரண்டாவது,
+// 5க்கு → ஐந்துக்கு, 21ஆம் → இருபத்தொன்றாம்). Larger numbers and numerals
+// that stand alone are left as is.
+func NormalizeNumerals(s string) string {
+	return regexNumeralWord.ReplaceAllStringFunc(s, func(m string) string {
+		sm := regexNumeralWord.FindStringSubmatch(m)
+		w, ok := numeralWord(sm[1])
+		if !ok {
+			return m
+		}
+		sfx := sm[2]
+		if v, ok := numeralSuffixes[sfx]; ok {
+			sfx = v
+		}
+		return joinSandhi(w, sfx)
+	})
+}
+
+// WithNumeralNormalization replaces numerals attached to Tamil words with
+// their word forms using NormalizeNumerals before the input is encoded.
+func WithNumeralNormalization() Option {
+	return func(k *TAphone) {
+		k.addFilter("numerals", NormalizeNumerals)
+	}
+}
+
+// numeralWord returns the Tamil word form of a numeral of up to two
+// digits.
+func numeralWord(digits string) (string, bool) {
+	n := 0
+	for _, r := range digits {
+		if r >= '௦' && r <= '௯' {
+			r = '0' + r - '௦'
+		}
+		n = n*10 + int(r-'0')
+		if n > 99 {
+			return "", false
+		}
+	}
+
+	switch {
+	case n < len(numeralWords):
+		return numeralWords[n], true
+	case n%10 == 0:
+		return tens[n/10], true
+	}
+	return joinSandhi(tensCombining[n/10], numeralWords[n%10]), true
+}
+
+// joinSandhi joins a word and a suffix. A vowel initial suffix merges
+// with the word's final consonant, dropping a final short u (இரண்டு + ஆம்
+// → இரண்டாம், இருபத்து + ஒன்று → இருபத்தொன்று). A final ம் is kept
+// before ஆ (பூஜ்ஜியம் + ஆவது → பூஜ்ஜியமாவது) and becomes the oblique த்த
+// before other vowels (பூஜ்ஜியம் + இல் → பூஜ்ஜியத்தில்).
+func joinSandhi(word, sfx string) string {
+	v, size := utf8.DecodeRuneInString(sfx)
+	sign, ok := vowelSigns[v]
+	if !ok {
+		return word + sfx
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ம்") && v != 'ஆ':
+		word = strings.TrimSuffix(word, "ம்") + "த்த"
+	case strings.HasSuffix(word, "்"):
+		word = strings.TrimSuffix(word, "்")
+	case strings.HasSuffix(word, "ு"):
+		word = strings.TrimSuffix(word, "ு")
+	default:
+		return word + sfx
+	}
+	return word + sign + sfx[size:]
+}
@@ -0,0 +1,41 @@
+package taphone
+
+import "testing"
+
+func TestNormalizeNumerals(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"ordinal", "2வது", "இரண்டாவது"},
+		{"ordinal of zero", "0வது", "பூஜ்ஜியமாவது"},
+		{"vowel initial ordinal", "10ஆவது", "பத்தாவது"},
+		{"date ordinal", "21ஆம்", "இருபத்தொன்றாம்"},
+		{"short date ordinal", "5ம்", "ஐந்தாம்"},
+		{"locative", "3ல்", "மூன்றில்"},
+		{"locative of zero", "0ல்", "பூஜ்ஜியத்தில்"},
+		{"consonant initial suffix", "5க்கு", "ஐந்துக்கு"},
+		{"tens", "90வது", "தொண்ணூறாவது"},
+		{"tens and units", "99ஆம்", "தொண்ணூற்றொன்பதாம்"},
+		{"tamil digits", "௨௧ஆம்", "இருபத்தொன்றாம்"},
+		{"in a sentence", "அவன் 1வது இடம்", "அவன் ஒன்றாவது இடம்"},
+		{"standalone numeral", "2 பேர்", "2 பேர்"},
+		{"over 99", "100வது", "100வது"},
+		{"latin suffix", "2nd", "2nd"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := NormalizeNumerals(c.input); got != c.want {
+				t.Errorf("NormalizeNumerals(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithNumeralNormalization(t *testing.T) {
+	k := New(WithNumeralNormalization())
+	if got, want := k.EncodeLevel("2வது", Key2), New().EncodeLevel("இரண்டாவது", Key2); got != want {
+		t.Errorf("EncodeLevel(2வது) = %q, want %q", got, want)
+	}
+}
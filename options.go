@@ -0,0 +1,43 @@
+package taphone
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed data/stopwords.txt
+var defaultStopwordList string
+
+// defaultStopwords is the default stopword set used by EncodePhrase.
+var defaultStopwords = makeSet(DefaultStopwords())
+
+// Option configures a TAphone encoder.
+type Option func(*TAphone)
+
+// DefaultStopwords returns the embedded default list of Tamil stopwords.
+func DefaultStopwords() []string {
+	var out []string
+	for _, l := range strings.Split(defaultStopwordList, "\n") {
+		l = strings.TrimSpace(l)
+		if l != "" && !strings.HasPrefix(l, "#") {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// WithStopwords replaces the default stopword set used by EncodePhrase
+// with the given words. Passing no words disables stopword removal.
+func WithStopwords(words ...string) Option {
+	return func(k *TAphone) {
+		k.stopwords = makeSet(words)
+	}
+}
+
+func makeSet(words []string) map[string]bool {
+	out := make(map[string]bool, len(words))
+	for _, w := range words {
+		out[w] = true
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+package taphone
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Alphabet is a phonetic alphabet that phonemes are rendered in.
+type Alphabet int
+
+// Phonetic alphabets.
+const (
+	IPA Alphabet = iota
+	XSAMPA
+)
+
+// String returns the alphabet's name as used in the SSML alphabet
+// attribute.
+func (a Alphabet) String() string {
+	if a == XSAMPA {
+		return "x-sampa"
+	}
+	return "ipa"
+}
+
+// phone is a phoneme in IPA and X-SAMPA.
+type phone struct {
+	ipa, sampa string
+}
+
+var (
+	phoneVowels = map[rune]phone{
+		'அ': {"a", "a"}, 'ஆ': {"aː", "a:"}, 'இ': {"i", "i"}, 'ஈ': {"iː", "i:"},
+		'உ': {"u", "u"}, 'ஊ': {"uː", "u:"}, 'எ': {"e", "e"}, 'ஏ': {"eː", "e:"},
+		'ஐ': {"ai̯", "ai_^"}, 'ஒ': {"o", "o"}, 'ஓ': {"oː", "o:"}, 'ஔ': {"au̯", "au_^"},
+	}
+
+	// phoneSigns maps vowel signs to the vowel letters they stand for.
+	phoneSigns = map[rune]rune{
+		'ா': 'ஆ', 'ி': 'இ', 'ீ': 'ஈ', 'ு': 'உ', 'ூ': 'ஊ', 'ெ': 'எ', 'ே': 'ஏ',
+		'ை': 'ஐ', 'ொ': 'ஒ', 'ோ': 'ஓ', 'ௌ': 'ஔ',
+	}
+
+	phoneConsonants = map[rune]phone{
+		'ங': {"ŋ", "N"}, 'ஞ': {"ɲ", "J"}, 'ண': {"ɳ", "n`"}, 'ந': {"n̪", "n_d"},
+		'ம': {"m", "m"}, 'ன': {"n", "n"}, 'ய': {"j", "j"}, 'ர': {"ɾ", "4"},
+		'ல': {"l", "l"}, 'வ': {"ʋ", "P"}, 'ழ': {"ɻ", "r\\`"}, 'ள': {"ɭ", "l`"},
+		'ற': {"r", "r"}, 'ஜ': {"dʒ", "dZ"}, 'ஷ': {"ʂ", "s`"}, 'ஸ': {"s", "s"},
+		'ஶ': {"ʃ", "S"}, 'ஹ': {"h", "h"}, 'ஃ': {"h", "h"},
+	}
+
+	// phoneStops are the allophones of the stops: word initially or
+	// geminated, after a nasal, and between vowels or after other
+	// consonants.
+	phoneStops = map[rune][3]phone{
+		'க': {{"k", "k"}, {"ɡ", "g"}, {"ɣ", "G"}},
+		'ச': {{"tʃ", "tS"}, {"dʒ", "dZ"}, {"s", "s"}},
+		'ட': {{"ʈ", "t`"}, {"ɖ", "d`"}, {"ɖ", "d`"}},
+		'த': {{"t̪", "t_d"}, {"d̪", "d_d"}, {"ð", "D"}},
+		'ப': {{"p", "p"}, {"b", "b"}, {"b", "b"}},
+	}
+
+	// phoneAytham are the foreign sounds written with ஃ before a letter
+	// (ஃபேன், ஃஜூ).
+	phoneAytham = map[rune]phone{'ப': {"f", "f"}, 'ஜ': {"z", "z"}}
+
+	phoneNasals = map[rune]bool{'ங': true, 'ஞ': true, 'ண': true, 'ந': true, 'ம': true, 'ன': true}
+)
+
+// syllable is a consonant, vowel or consonant-vowel unit of a word.
+type syllable struct {
+	cons  rune
+	vowel rune
+}
+
+// Phonemes returns the pronunciation of a Tamil word, or of each word of
+// a phrase, in the given alphabet (வணக்கம் → ʋaɳakkam). Stops are voiced
+// or lenited by context as in standard spoken Tamil: voiceless word
+// initially and when geminated, voiced after nasals and fricated between
+// vowels. Non-Tamil characters are ignored.
+func Phonemes(text string, a Alphabet) string {
+	var out []string
+	for _, w := range strings.Fields(text) {
+		if p := wordPhonemes(w, a); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// SSML returns an SSML phoneme element that tells a speech synthesiser
+// how to pronounce a Tamil word or phrase.
+func SSML(text string, a Alphabet) string {
+	return fmt.Sprintf(`<phoneme alphabet="%s" ph="%s">%s</phoneme>`,
+		a, html.EscapeString(Phonemes(text, a)), html.EscapeString(text))
+}
+
+func wordPhonemes(w string, a Alphabet) string {
+	syls := syllables(w)
+
+	var b strings.Builder
+	add := func(p phone) {
+		if a == XSAMPA {
+			b.WriteString(p.sampa)
+		} else {
+			b.WriteString(p.ipa)
+		}
+	}
+
+	for i, s := range syls {
+		if s.cons != 0 {
+			var prev syllable
+			if i > 0 {
+				prev = syls[i-1]
+			}
+			bare := i > 0 && prev.cons != 0 && prev.vowel == 0
+
+			switch {
+			case s.cons == 'ஃ' && i+1 < len(syls) && phoneAytham[syls[i+1].cons] != phone{}:
+				// ஃ marks the foreign sound of the next letter.
+			case prev.cons == 'ஃ' && phoneAytham[s.cons] != phone{}:
+				add(phoneAytham[s.cons])
+			case s.cons == 'ற' && bare && prev.cons == 'ன':
+				// ன்ற is pronounced ndr.
+				add(phone{"d", "d"})
+				add(phoneConsonants['ற'])
+			case s.cons == 'ற' && s.vowel == 0 && i+1 < len(syls) && syls[i+1].cons == 'ற':
+				// ற்ற is pronounced tr.
+				add(phone{"t", "t"})
+			case phoneStops[s.cons] != [3]phone{}:
+				st := phoneStops[s.cons]
+				switch {
+				case i == 0 || (bare && prev.cons == s.cons):
+					add(st[0])
+				case s.vowel == 0 && i+1 < len(syls) && phoneStops[syls[i+1].cons] != [3]phone{}:
+					// The first of a geminate or stop cluster.
+					add(st[0])
+				case bare && phoneNasals[prev.cons]:
+					add(st[1])
+				case bare && phoneStops[prev.cons] != [3]phone{}:
+					// Clusters of unlike stops (ட்க) stay voiceless.
+					add(st[0])
+				default:
+					add(st[2])
+				}
+			default:
+				add(phoneConsonants[s.cons])
+			}
+		}
+
+		switch {
+		case s.vowel == 0:
+		case s.vowel == 'உ' && s.cons != 0 && i == len(syls)-1:
+			// Word final short u is unrounded.
+			add(phone{"ɯ", "M"})
+		default:
+			add(phoneVowels[s.vowel])
+		}
+	}
+	return b.String()
+}
+
+// syllables splits a Tamil word into syllables. A consonant without a
+// vowel sign carries the inherent அ, and one with a virama has no vowel.
+func syllables(w string) []syllable {
+	var out []syllable
+	for _, r := range w {
+		switch {
+		case phoneVowels[r] != phone{}:
+			out = append(out, syllable{vowel: r})
+		case phoneConsonants[r] != phone{} || phoneStops[r] != [3]phone{}:
+			v := 'அ'
+			if r == 'ஃ' {
+				v = 0
+			}
+			out = append(out, syllable{cons: r, vowel: v})
+		case len(out) > 0 && out[len(out)-1].cons != 0:
+			last := &out[len(out)-1]
+			if r == '்' {
+				last.vowel = 0
+			} else if v, ok := phoneSigns[r]; ok {
+				last.vowel = v
+			}
+		}
+	}
+	return out
+}
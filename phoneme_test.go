@@ -0,0 +1,51 @@
+package taphone
+
+import "testing"
+
+func TestPhonemes(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		ipa   string
+		sampa string
+	}{
+		{"geminate stop", "வணக்கம்", "ʋaɳakkam", "Pan`akkam"},
+		{"stop after a nasal", "தங்கம்", "t̪aŋɡam", "t_daNgam"},
+		{"stop between vowels", "பகல்", "paɣal", "paGal"},
+		{"long vowel", "அப்பா", "appaː", "appa:"},
+		{"ndr", "என்று", "endrɯ", "endrM"},
+		{"tr", "வெற்றி", "ʋetri", "Petri"},
+		{"aytham", "ஃபேன்", "feːn", "fe:n"},
+		{"diphthong", "மழை", "maɻai̯", "mar\\`ai_^"},
+		{"unlike stops", "நட்பு", "n̪aʈpɯ", "n_dat`pM"},
+		{"phrase", "அவன் வந்தான்", "aʋan ʋan̪d̪aːn", "aPan Pan_dd_da:n"},
+		{"not tamil", "hello", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Phonemes(c.input, IPA); got != c.ipa {
+				t.Errorf("Phonemes(%q, IPA) = %q, want %q", c.input, got, c.ipa)
+			}
+			if got := Phonemes(c.input, XSAMPA); got != c.sampa {
+				t.Errorf("Phonemes(%q, XSAMPA) = %q, want %q", c.input, got, c.sampa)
+			}
+		})
+	}
+}
+
+func TestSSML(t *testing.T) {
+	cases := []struct {
+		input string
+		a     Alphabet
+		want  string
+	}{
+		{"அம்மா", IPA, `<phoneme alphabet="ipa" ph="ammaː">அம்மா</phoneme>`},
+		{"அம்மா", XSAMPA, `<phoneme alphabet="x-sampa" ph="amma:">அம்மா</phoneme>`},
+		{"மழை <b>", XSAMPA, "<phoneme alphabet=\"x-sampa\" ph=\"mar\\`ai_^\">மழை &lt;b&gt;</phoneme>"},
+	}
+	for _, c := range cases {
+		if got := SSML(c.input, c.a); got != c.want {
+			t.Errorf("SSML(%q, %v) = %q, want %q", c.input, c.a, got, c.want)
+		}
+	}
+}
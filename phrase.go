@@ -0,0 +1,50 @@
+package taphone
+
+import (
+	"strings"
+)
+
+// EncodePhrase encodes a phrase of Tamil words to combined phrase keys.
+// The phrase is run through the default Analyzer, which drops stopwords
+// and non-Tamil tokens, and the keys of the remaining words are joined by
+// spaces, or the separator set with WithPhraseSeparator, at each level.
+func (k *TAphone) EncodePhrase(input string) (string, string, string) {
+	keys := k.phraseKeys(input)
+	return strings.Join(keys[Key0], k.phraseSep), strings.Join(keys[Key1], k.phraseSep), strings.Join(keys[Key2], k.phraseSep)
+}
+
+// Word separators for EncodePhrase keys.
+const (
+	// SeparatorSpace separates word keys with spaces, for backends that
+	// index each word key as a token (VNKKM NNPRKL).
+	SeparatorSpace = " "
+
+	// SeparatorCode separates word keys with an underscore, which is not
+	// part of the key alphabet, for backends that index the phrase key as
+	// a single token but need word boundaries (VNKKM_NNPRKL).
+	SeparatorCode = "_"
+
+	// SeparatorNone concatenates word keys (VNKKMNNPRKL), so that phrases
+	// match regardless of where words are split.
+	SeparatorNone = ""
+)
+
+// WithPhraseSeparator sets the separator between word keys in the keys
+// returned by EncodePhrase. The default is SeparatorSpace.
+func WithPhraseSeparator(sep string) Option {
+	return func(k *TAphone) {
+		k.phraseSep = sep
+	}
+}
+
+// phraseKeys returns the keys of the words of a phrase at each level, as
+// analysed by the default analyzer.
+func (k *TAphone) phraseKeys(input string) [3][]string {
+	var keys [3][]string
+	for _, t := range k.Analyzer().Analyze(input) {
+		for l := range keys {
+			keys[l] = append(keys[l], t.Keys[l])
+		}
+	}
+	return keys
+}
@@ -0,0 +1,54 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodePhrase(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  []Option
+		input string
+		keys  [3]string
+	}{
+		{"words", nil, "வணக்கம் நண்பர்கள்", [3]string{"VNKKM NNPRKL", "VNKKM NNPRKL", "VNKKM NNPRKL"}},
+		{"default stopwords", nil, "அவர் ஒரு நல்ல மனிதர்", [3]string{"NLL MN3TR", "NLL MN13T1R", "NLL MN13T1R"}},
+		{"no stopwords", []Option{WithStopwords()}, "அவர் ஒரு நல்ல மனிதர்",
+			[3]string{"AVR OR NLL MN3TR", "AVR OR NLL MN13T1R", "AVR OR4 NLL MN13T1R"}},
+		{"custom stopwords", []Option{WithStopwords("நல்ல")}, "அவர் ஒரு நல்ல மனிதர்",
+			[3]string{"AVR OR MN3TR", "AVR OR MN13T1R", "AVR OR4 MN13T1R"}},
+		{"non-Tamil tokens", nil, "hello வணக்கம் 123!", [3]string{"VNKKM", "VNKKM", "VNKKM"}},
+		{"code separator", []Option{WithPhraseSeparator(SeparatorCode)}, "வணக்கம் நண்பர்கள்",
+			[3]string{"VNKKM_NNPRKL", "VNKKM_NNPRKL", "VNKKM_NNPRKL"}},
+		{"no separator", []Option{WithPhraseSeparator(SeparatorNone)}, "வணக்கம் நண்பர்கள்",
+			[3]string{"VNKKMNNPRKL", "VNKKMNNPRKL", "VNKKMNNPRKL"}},
+		{"single word with a separator", []Option{WithPhraseSeparator(SeparatorCode)}, "வணக்கம்",
+			[3]string{"VNKKM", "VNKKM", "VNKKM"}},
+		{"only stopwords", nil, "ஒரு இந்த", [3]string{}},
+		{"empty", nil, "", [3]string{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k0, k1, k2 := New(c.opts...).EncodePhrase(c.input)
+			if got := [3]string{k0, k1, k2}; got != c.keys {
+				t.Errorf("EncodePhrase(%q) = %q, want %q", c.input, got, c.keys)
+			}
+		})
+	}
+}
+
+func TestDefaultStopwords(t *testing.T) {
+	words := DefaultStopwords()
+	if len(words) == 0 || words[0] != "ஒரு" {
+		t.Errorf("DefaultStopwords() = %q, want the embedded list", words)
+	}
+	if !reflect.DeepEqual(makeSet(words), defaultStopwords) {
+		t.Error("DefaultStopwords() doesn't match the default set")
+	}
+	for _, w := range words {
+		if w == "" || w[0] == '#' {
+			t.Errorf("DefaultStopwords() has %q", w)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package taphone
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Result is the result of encoding an input in a pipeline.
+type Result struct {
+	Input string
+	Keys
+}
+
+// Pipe encodes the strings received from in concurrently and sends their
+// keys on the returned channel, in no particular order. The returned
+// channel is closed once in is closed and all inputs are encoded, or when
+// ctx is cancelled.
+func (k *TAphone) Pipe(ctx context.Context, in <-chan string) <-chan Result {
+	var (
+		out  = make(chan Result)
+		wg   sync.WaitGroup
+		p, _ = k.newProgress(nil)
+	)
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for s := range recv(ctx, in) {
+				select {
+				case out <- Result{Input: s, Keys: k.EncodeKeys(s)}:
+					p.add(1)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		p.finish()
+		close(out)
+	}()
+	return out
+}
+
+// PipeOrdered is like Pipe but sends the results in the order the inputs
+// were received.
+func (k *TAphone) PipeOrdered(ctx context.Context, in <-chan string) <-chan Result {
+	type job struct {
+		input string
+		res   chan Result
+	}
+
+	var (
+		n     = runtime.NumCPU()
+		jobs  = make(chan job)
+		queue = make(chan chan Result, 2*n)
+		out   = make(chan Result)
+		p, _  = k.newProgress(nil)
+	)
+
+	// Hand inputs to the workers and queue their result channels in
+	// input order.
+	go func() {
+		defer close(jobs)
+		defer close(queue)
+		for s := range recv(ctx, in) {
+			j := job{input: s, res: make(chan Result, 1)}
+			select {
+			case queue <- j.res:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		go func() {
+			for j := range jobs {
+				j.res <- Result{Input: j.input, Keys: k.EncodeKeys(j.input)}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(out)
+		defer p.finish()
+		for res := range queue {
+			var r Result
+			select {
+			case r = <-res:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out <- r:
+				p.add(1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// recv returns a channel that relays the values received from in until in
+// is closed or ctx is cancelled.
+func recv(ctx context.Context, in <-chan string) <-chan string {
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case s, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- s:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,90 @@
+package taphone
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+var pipeInputs = []string{"வணக்கம்", "முருகன்", "தமிழ்", "hello", "", "மரம்", "கண்ணன்", "செல்வம்"}
+
+func feed(inputs []string) <-chan string {
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, s := range inputs {
+			in <- s
+		}
+	}()
+	return in
+}
+
+func TestPipe(t *testing.T) {
+	k := New()
+	cases := []struct {
+		name    string
+		pipe    func(context.Context, <-chan string) <-chan Result
+		ordered bool
+	}{
+		{"unordered", k.Pipe, false},
+		{"ordered", k.PipeOrdered, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			for r := range c.pipe(context.Background(), feed(pipeInputs)) {
+				if r.Keys != k.EncodeKeys(r.Input) {
+					t.Errorf("keys of %q = %+v, want %+v", r.Input, r.Keys, k.EncodeKeys(r.Input))
+				}
+				got = append(got, r.Input)
+			}
+
+			want := append([]string(nil), pipeInputs...)
+			if !c.ordered {
+				sort.Strings(got)
+				sort.Strings(want)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("got %d results, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("results = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPipeCancel(t *testing.T) {
+	k := New()
+	cases := []struct {
+		name string
+		pipe func(context.Context, <-chan string) <-chan Result
+	}{
+		{"unordered", k.Pipe},
+		{"ordered", k.PipeOrdered},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			in := make(chan string) // never closed
+			out := c.pipe(ctx, in)
+			in <- "வணக்கம்"
+			cancel()
+
+			done := make(chan bool)
+			go func() {
+				for range out {
+				}
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("output not closed after cancel")
+			}
+		})
+	}
+}
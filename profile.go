@@ -0,0 +1,123 @@
+package taphone
+
+import (
+	"sort"
+	"strings"
+)
+
+// Profile is a named set of preprocessing rules tuned for a kind of
+// input. Profiles are applied to the input before it is encoded.
+type Profile struct {
+	Name string
+
+	// Replace is a list of substitutions applied to the input in order.
+	Replace [][2]string
+
+	// Drop are words removed from the input, with or without a
+	// trailing full stop.
+	Drop []string
+
+	// DropInitials removes initials (மு., க.) from the input.
+	DropInitials bool
+
+	// Suffixes are stripped from the end of words, longest first, as long
+	// as at least two graphemes of the word remain.
+	Suffixes []string
+}
+
+// NameProfile is tuned for person names. It drops initials and titles,
+// strips honorific suffixes (கண்ணம்மாள் → கண்ண) and folds Sanskrit-origin
+// clusters and Grantha letters to the native spellings commonly used for
+// the same names (லக்ஷ்மி → லட்சுமி, ஜெயா → செயா), so that variant
+// spellings of a name key alike.
+var NameProfile = Profile{
+	Name: "name",
+	Replace: [][2]string{
+		{"க்ஷ்மி", "ட்சுமி"}, {"க்ஷ", "ட்ச"}, {"ஸ்ரீ", "சிரீ"}, {"ஶ்ரீ", "சிரீ"},
+		{"ஸ்வா", "சுவா"}, {"சுவாமி", "சாமி"}, {"ஸ்ண", "சண"},
+		{"ஜ", "ச"}, {"ஷ", "ச"}, {"ஸ", "ச"}, {"ஶ", "ச"}, {"ஹ", "க"},
+	},
+	Drop: []string{
+		"திரு", "திருமதி", "செல்வி", "டாக்டர்", "திருவாளர்", "சிரீ", "அம்மாள்",
+	},
+	DropInitials: true,
+	Suffixes:     []string{"யம்மாள்", "வம்மாள்", "அம்மாள்", "ம்மாள்"},
+}
+
+// ASRProfile is tuned for speech-to-text transcripts. Speech recognisers
+// drop and add gemination, confuse ழ with ள and write Grantha letters
+// inconsistently, so the profile removes gemination (வணக்கம் → வணகம்) and
+// folds these letters. It should be applied to the transcripts and to the
+// reference text they are searched against alike.
+var ASRProfile = Profile{
+	Name: "asr",
+	Replace: append(degeminate(),
+		[2]string{"ழ", "ள"}, [2]string{"ஜ", "ச"}, [2]string{"ஷ", "ச"}, [2]string{"ஸ", "ச"},
+		[2]string{"ஶ", "ச"}, [2]string{"ஹ", "க"},
+	),
+}
+
+// WithProfile applies the rules of a profile to the input before it is
+// encoded.
+func WithProfile(p Profile) Option {
+	var (
+		drop = makeSet(p.Drop)
+		sfx  = append([]string(nil), p.Suffixes...)
+	)
+	sort.Slice(sfx, func(i, j int) bool { return len(sfx[i]) > len(sfx[j]) })
+
+	return func(k *TAphone) {
+		k.addFilter("profile:"+p.Name, func(s string) string {
+			return p.apply(s, drop, sfx)
+		})
+		k.profiles = append(k.profiles, p)
+	}
+}
+
+// apply applies the profile to s. drop and sfx are the profile's Drop
+// and Suffixes prepared for lookup.
+func (p Profile) apply(s string, drop map[string]bool, sfx []string) string {
+	for _, r := range p.Replace {
+		s = strings.ReplaceAll(s, r[0], r[1])
+	}
+
+	var out []string
+	for _, w := range strings.Fields(s) {
+		if drop[strings.TrimSuffix(w, ".")] || (p.DropInitials && isInitials(w)) {
+			continue
+		}
+		for _, x := range sfx {
+			if t := strings.TrimSuffix(w, x); t != w && len(Graphemes(t)) >= 2 {
+				w = t
+				break
+			}
+		}
+		out = append(out, w)
+	}
+	return strings.Join(out, " ")
+}
+
+// degeminate returns replacements of doubled consonants with single ones
+// (க்க → க).
+func degeminate() [][2]string {
+	out := make([][2]string, 0, len(consonants))
+	for c := range consonants {
+		out = append(out, [2]string{c + "்" + c, c})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+// isInitials reports whether w is one or more single grapheme initials
+// each followed by a full stop (மு., மு.க.).
+func isInitials(w string) bool {
+	if !strings.HasSuffix(w, ".") {
+		return false
+	}
+	for _, p := range strings.Split(strings.TrimSuffix(w, "."), ".") {
+		if len(Graphemes(p)) != 1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package taphone
+
+import "testing"
+
+func TestNameProfile(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+	}{
+		{"ksha cluster", "லக்ஷ்மி", "லட்சுமி"},
+		{"grantha letters", "ஜெயா", "செயா"},
+		{"sri", "ஸ்ரீராம்", "சிரீராம்"},
+		{"titles and initials", "திரு. மு.க. ஸ்டாலின்", "ஸ்டாலின்"},
+		{"spaced initials", "மு. முருகன்", "முருகன்"},
+		{"honorific suffix", "கண்ணம்மாள்", "கண்ண"},
+		{"honorific suffix after i", "வள்ளியம்மாள்", "வள்ளி"},
+		{"honorific word", "சீதா அம்மாள்", "சீதா"},
+	}
+	k := New(WithProfile(NameProfile))
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if a, b := k.EncodeLevel(c.a, Key2), k.EncodeLevel(c.b, Key2); a != b {
+				t.Errorf("key2 of %q = %q, of %q = %q, want equal", c.a, a, c.b, b)
+			}
+		})
+	}
+}
+
+func TestASRProfile(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{"gemination", "வணக்கம்", "வணகம்", true},
+		{"added gemination", "படம்", "பட்டம்", true},
+		{"zha and lla", "பழம்", "பளம்", true},
+		{"grantha ja", "ஜெயா", "செயா", true},
+		{"grantha sha", "ஷண்முகம்", "சண்முகம்", true},
+		{"grantha ha", "ஹரி", "கரி", true},
+		{"different words", "படம்", "குடம்", false},
+	}
+	k := New(WithProfile(ASRProfile))
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, b := k.EncodeLevel(c.a, Key2), k.EncodeLevel(c.b, Key2)
+			if (a == b) != c.same {
+				t.Errorf("key2 of %q = %q, of %q = %q, want equal %v", c.a, a, c.b, b, c.same)
+			}
+		})
+	}
+}
+
+func TestProfileApply(t *testing.T) {
+	p := Profile{
+		Name:         "test",
+		Replace:      [][2]string{{"ஜ", "ச"}},
+		Drop:         []string{"திரு"},
+		DropInitials: true,
+		Suffixes:     []string{"ன்", "வன்"},
+	}
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"திரு. ஜெகன்", "செக"},
+		{"திரு ஜெகன்", "செக"},
+		{"மு.க. மாதவன்", "மாத"},
+		{"அவன்", "அவ"},
+		{"வன்", "வன்"},
+		{"மு.கருணாநிதி", "மு.கருணாநிதி"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		k := &TAphone{}
+		WithProfile(p)(k)
+		if got := k.filters[0](c.input); got != c.want {
+			t.Errorf("apply(%q) = %q, want %q", c.input, got, c.want)
+		}
+	}
+}
+
+func TestIsInitials(t *testing.T) {
+	cases := map[string]bool{
+		"மு.": true, "மு.க.": true, "M.": true,
+		"மு": false, "முரு.": false, "மு.கருணா.": false, ".": false,
+	}
+	for w, want := range cases {
+		if got := isInitials(w); got != want {
+			t.Errorf("isInitials(%q) = %v, want %v", w, got, want)
+		}
+	}
+}
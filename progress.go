@@ -0,0 +1,158 @@
+package taphone
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// progressInterval is the minimum interval between progress reports.
+const progressInterval = 250 * time.Millisecond
+
+// Progress is a report on the progress of a bulk operation.
+type Progress struct {
+	// Done is the number of items (words, lines, rows, records) processed.
+	Done int
+
+	// Rate is the number of items processed per second.
+	Rate float64
+
+	// Elapsed is the time since the operation started.
+	Elapsed time.Duration
+
+	// ETA is the estimated time remaining. It is only known for input read
+	// from files and in-memory readers whose size is known, and is 0
+	// otherwise.
+	ETA time.Duration
+
+	// Finished is set on the last report of an operation.
+	Finished bool
+}
+
+// ProgressFunc receives progress reports.
+type ProgressFunc func(Progress)
+
+// WithProgress reports the progress of the encoder's bulk operations
+// (EncodeCSV, EncodeNDJSON, EncodeColumn, Pipe, PipeOrdered and loading
+// dictionaries that use the encoder) to fn, at most four times a second
+// and once when the operation finishes. fn is called synchronously and
+// should return quickly.
+func WithProgress(fn ProgressFunc) Option {
+	return func(k *TAphone) {
+		k.progress = fn
+	}
+}
+
+// progress tracks the progress of an operation. A nil *progress discards
+// reports.
+type progress struct {
+	fn ProgressFunc
+
+	mu    sync.Mutex
+	start time.Time
+	last  time.Time
+	done  int
+
+	// r counts the bytes read from the input, of size bytes.
+	r    *countReader
+	size int64
+}
+
+// newProgress returns a tracker for an operation that reads from r, and
+// the reader it should read from instead. r may be nil. It returns a nil
+// tracker and r unchanged if progress isn't reported.
+func (k *TAphone) newProgress(r io.Reader) (*progress, io.Reader) {
+	if k.progress == nil {
+		return nil, r
+	}
+
+	now := time.Now()
+	p := &progress{fn: k.progress, start: now, last: now}
+	if r != nil {
+		p.size = readerSize(r)
+		p.r = &countReader{r: r}
+		r = p.r
+	}
+	return p, r
+}
+
+// add records n processed items.
+func (p *progress) add(n int) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += n
+	if now := time.Now(); now.Sub(p.last) >= progressInterval {
+		p.last = now
+		p.report(now, false)
+	}
+}
+
+// finish sends the final report.
+func (p *progress) finish() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.report(time.Now(), true)
+}
+
+func (p *progress) report(now time.Time, finished bool) {
+	pr := Progress{Done: p.done, Elapsed: now.Sub(p.start), Finished: finished}
+	if s := pr.Elapsed.Seconds(); s > 0 {
+		pr.Rate = float64(p.done) / s
+	}
+	if !finished && p.size > 0 && p.r != nil {
+		if n := p.r.count(); n > 0 && n < p.size {
+			pr.ETA = time.Duration(float64(pr.Elapsed) * float64(p.size-n) / float64(n))
+		}
+	}
+	p.fn(pr)
+}
+
+// countReader counts the bytes read through it.
+type countReader struct {
+	r  io.Reader
+	mu sync.Mutex
+	n  int64
+}
+
+func (c *countReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.mu.Lock()
+	c.n += int64(n)
+	c.mu.Unlock()
+	return n, err
+}
+
+func (c *countReader) count() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// readerSize returns the number of bytes left to read from r, or 0 if it
+// isn't known.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case *os.File:
+		fi, err := v.Stat()
+		if err != nil || !fi.Mode().IsRegular() {
+			return 0
+		}
+		off, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0
+		}
+		return fi.Size() - off
+	}
+	return 0
+}
@@ -0,0 +1,112 @@
+package taphone
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithProgress(t *testing.T) {
+	cases := []struct {
+		name string
+		run  func(k *TAphone) error
+		done int
+	}{
+		{"csv", func(k *TAphone) error {
+			return k.EncodeCSV(strings.NewReader("name\nமரம்\nகல்\n"), io.Discard, "name")
+		}, 2},
+		{"ndjson", func(k *TAphone) error {
+			return k.EncodeNDJSON(strings.NewReader(`{"a":"மரம்"} {"a":"கல்"} {}`), io.Discard, "a")
+		}, 3},
+		{"pipe", func(k *TAphone) error {
+			for range k.Pipe(context.Background(), feed(pipeInputs)) {
+			}
+			return nil
+		}, len(pipeInputs)},
+		{"ordered pipe", func(k *TAphone) error {
+			for range k.PipeOrdered(context.Background(), feed(pipeInputs)) {
+			}
+			return nil
+		}, len(pipeInputs)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var reports []Progress
+			k := New(WithProgress(func(p Progress) { reports = append(reports, p) }))
+			if err := c.run(k); err != nil {
+				t.Fatal(err)
+			}
+			if len(reports) == 0 {
+				t.Fatal("no progress reports")
+			}
+			last := reports[len(reports)-1]
+			if !last.Finished || last.Done != c.done || last.ETA != 0 {
+				t.Errorf("last report = %+v, want finished with %d done", last, c.done)
+			}
+			for _, r := range reports[:len(reports)-1] {
+				if r.Finished {
+					t.Errorf("report %+v before the last is finished", r)
+				}
+			}
+		})
+	}
+}
+
+func TestProgressReport(t *testing.T) {
+	var got Progress
+	p := &progress{
+		fn:    func(pr Progress) { got = pr },
+		start: time.Now().Add(-2 * time.Second),
+		r:     &countReader{n: 25},
+		size:  100,
+		done:  10,
+	}
+	p.add(10)
+	if got.Done != 20 || got.Finished {
+		t.Fatalf("report = %+v, want 20 done", got)
+	}
+	if got.Rate < 9 || got.Rate > 10 {
+		t.Errorf("Rate = %v, want about 10", got.Rate)
+	}
+	if got.ETA < 6*time.Second || got.ETA > 7*time.Second {
+		t.Errorf("ETA = %v, want about 6s", got.ETA)
+	}
+
+	// A nil tracker discards reports.
+	var np *progress
+	np.add(1)
+	np.finish()
+}
+
+func TestReaderSize(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "progress")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	f.WriteString("0123456789")
+	f.Seek(4, io.SeekStart)
+
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	cases := []struct {
+		name string
+		r    io.Reader
+		want int64
+	}{
+		{"strings reader", strings.NewReader("abc"), 3},
+		{"buffer", bytes.NewBufferString("abcd"), 4},
+		{"file", f, 6},
+		{"pipe", pr, 0},
+	}
+	for _, c := range cases {
+		if got := readerSize(c.r); got != c.want {
+			t.Errorf("readerSize(%s) = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package pseudonym replaces Tamil names with consistent pseudonyms that
+// preserve their phonetic relationships. A pseudonym has two parts, a
+// family derived from the keyed hash of the name's key0 and a member
+// derived from the keyed hash of its key2. Names that sound alike share a
+// family (முருகன், முருகண்) and spellings of the same pronunciation share
+// the whole pseudonym, so that anonymised datasets can still be used to
+// study name matching. Pseudonyms can't be reversed without the secret.
+package pseudonym
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cmrajan/taphone"
+)
+
+const (
+	familyLen = 8
+	memberLen = 4
+)
+
+// Pseudonymizer generates pseudonyms under a secret.
+type Pseudonymizer struct {
+	enc    *taphone.TAphone
+	secret []byte
+}
+
+// New returns a pseudonymizer that derives pseudonyms under the given
+// secret. The same secret always produces the same pseudonyms.
+func New(enc *taphone.TAphone, secret []byte) *Pseudonymizer {
+	return &Pseudonymizer{enc: enc, secret: secret}
+}
+
+// Name returns the pseudonym of a name. Each word of a multi-word name
+// is replaced by its own pseudonym. Words without Tamil letters are
+// replaced by a pseudonym derived from the word itself, with matching
+// family and member parts.
+func (p *Pseudonymizer) Name(name string) string {
+	words := strings.Fields(name)
+	for i, w := range words {
+		words[i] = p.word(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// Family returns the family part of the pseudonym of a single word name.
+// Names with equal families sound alike.
+func (p *Pseudonymizer) Family(name string) string {
+	s := p.word(name)
+	if i := strings.IndexByte(s, '-'); i > -1 {
+		return s[:i]
+	}
+	return s
+}
+
+// Rewrite copies CSV from r to w, replacing the values of the named
+// columns with their pseudonyms. The first row of r must be a header.
+func (p *Pseudonymizer) Rewrite(r io.Reader, w io.Writer, columns ...string) error {
+	var (
+		cr = csv.NewReader(r)
+		cw = csv.NewWriter(w)
+	)
+	cr.FieldsPerRecord = -1
+
+	hdr, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("error reading header: %v", err)
+	}
+
+	var cols []int
+	for _, c := range columns {
+		i := indexOf(hdr, c)
+		if i < 0 {
+			return fmt.Errorf("header has no %s column", c)
+		}
+		cols = append(cols, i)
+	}
+	if len(cols) == 0 {
+		return errors.New("no columns to rewrite")
+	}
+	if err := cw.Write(hdr); err != nil {
+		return err
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for _, i := range cols {
+			if i < len(row) {
+				row[i] = p.Name(row[i])
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// word returns the pseudonym of a single word.
+func (p *Pseudonymizer) word(w string) string {
+	k0, _, k2 := p.enc.EncodeHMAC(w, p.secret)
+	if k0 == "" {
+		h := hmac.New(sha256.New, p.secret)
+		h.Write([]byte(strings.ToLower(w)))
+		k0 = hex.EncodeToString(h.Sum(nil))
+		k2 = k0
+	}
+	return strings.ToUpper(k0[:familyLen] + "-" + k2[:memberLen])
+}
+
+func indexOf(hdr []string, col string) int {
+	for i, h := range hdr {
+		if strings.EqualFold(strings.TrimSpace(h), col) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,92 @@
+package pseudonym
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+func TestName(t *testing.T) {
+	var (
+		p     = New(taphone.New(), []byte("secret"))
+		other = New(taphone.New(), []byte("other"))
+	)
+	cases := []struct {
+		name       string
+		a, b       string
+		p          *Pseudonymizer
+		sameFamily bool
+		same       bool
+	}{
+		{"same name", "முருகன்", "முருகன்", p, true, true},
+		{"soundalike", "முருகன்", "முருகண்", p, true, false},
+		{"different name", "முருகன்", "செல்வம்", p, false, false},
+		{"latin case", "Murugan", "MURUGAN", p, true, true},
+		{"latin spelling", "Murugan", "Muruhan", p, false, false},
+		{"other secret", "முருகன்", "முருகன்", other, false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, b := p.Name(c.a), c.p.Name(c.b)
+			if len(a) != familyLen+1+memberLen {
+				t.Errorf("Name(%q) = %q, want %d characters", c.a, a, familyLen+1+memberLen)
+			}
+			if got := p.Family(c.a) == c.p.Family(c.b); got != c.sameFamily {
+				t.Errorf("Family(%q) == Family(%q) is %v, want %v", c.a, c.b, got, c.sameFamily)
+			}
+			if got := a == b; got != c.same {
+				t.Errorf("Name(%q) = %q, Name(%q) = %q, want equal %v", c.a, a, c.b, b, c.same)
+			}
+		})
+	}
+}
+
+func TestNameWords(t *testing.T) {
+	p := New(taphone.New(), []byte("secret"))
+	got := p.Name("  முருகன்   செல்வம் ")
+	want := p.Name("முருகன்") + " " + p.Name("செல்வம்")
+	if got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	p := New(taphone.New(), []byte("secret"))
+	cases := []struct {
+		name    string
+		input   string
+		columns []string
+		want    string
+		err     string
+	}{
+		{
+			name:    "named columns",
+			input:   "id,Name,city\n1,முருகன்,சென்னை\n2\n",
+			columns: []string{"name"},
+			want:    "id,Name,city\n1," + p.Name("முருகன்") + ",சென்னை\n2\n",
+		},
+		{name: "missing column", input: "id,name\n", columns: []string{"father"}, err: "no father column"},
+		{name: "no columns", input: "id,name\n", err: "no columns"},
+		{name: "no header", input: "", columns: []string{"name"}, err: "error reading header"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := p.Rewrite(strings.NewReader(c.input), &out, c.columns...)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Errorf("Rewrite() error = %v, want %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != c.want {
+				t.Errorf("Rewrite() = %q, want %q", out.String(), c.want)
+			}
+		})
+	}
+}
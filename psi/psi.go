@@ -0,0 +1,197 @@
+// Package psi matches phonetic keys across organisations without sharing
+// the underlying records, in the manner of private set intersection.
+// One party exports a Bloom filter of its keyed phonetic hashes
+// (taphone.EncodeHMAC under a secret shared by the parties) and the other
+// checks its own records against the filter. Without the secret, the
+// filter reveals neither the words nor their keys. Anyone who holds the
+// secret can, however, test any word against the filter, so the secret
+// must be kept to the parties. Matches are probabilistic: a record may
+// falsely match at the filter's false positive rate.
+package psi
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/cmrajan/taphone"
+)
+
+// DefaultFalsePositiveRate is the default false positive rate of filters
+// created with Export.
+const DefaultFalsePositiveRate = 0.001
+
+// MaxFilterWords is the maximum size in 64-bit words of a filter that
+// Read accepts, 128 MiB, enough for about 70 million items at the
+// default false positive rate. MaxHashes is the maximum number of hash
+// functions it accepts.
+const (
+	MaxFilterWords = 1 << 24
+	MaxHashes      = 64
+)
+
+// readChunk is the number of 64-bit words Read reads at a time, so that
+// a header that claims a large filter can't allocate more memory than
+// the stream holds.
+const readChunk = 8192
+
+// magic identifies the serialised filter format.
+var magic = [4]byte{'T', 'P', 'S', '1'}
+
+// Filter is a Bloom filter of keyed phonetic hashes at one key level.
+type Filter struct {
+	Level taphone.KeyLevel
+
+	k    uint32
+	bits []uint64
+}
+
+// New returns an empty filter sized for n items at the given false
+// positive rate.
+func New(level taphone.KeyLevel, n int, fpRate float64) *Filter {
+	if n < 1 {
+		n = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = DefaultFalsePositiveRate
+	}
+
+	// Optimal number of bits and hash functions for n items.
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	if k < 1 {
+		k = 1
+	}
+	return &Filter{
+		Level: level,
+		k:     uint32(k),
+		bits:  make([]uint64, (uint64(m)+63)/64),
+	}
+}
+
+// Export returns a filter of the keyed hashes of words at the given
+// level under the shared secret, ready to be written out with WriteTo
+// and sent to the other party.
+func Export(enc *taphone.TAphone, secret []byte, level taphone.KeyLevel, words []string, fpRate float64) *Filter {
+	f := New(level, len(words), fpRate)
+	for _, w := range words {
+		if h := hash(enc, secret, level, w); h != "" {
+			f.AddHash(h)
+		}
+	}
+	return f
+}
+
+// Check reports whether word probably has a phonetic match in the
+// filter. It must be called with the secret the filter was exported
+// with.
+func (f *Filter) Check(enc *taphone.TAphone, secret []byte, word string) bool {
+	h := hash(enc, secret, f.Level, word)
+	return h != "" && f.HasHash(h)
+}
+
+// AddHash adds a keyed hash, as returned by taphone.EncodeHMAC, to the
+// filter.
+func (f *Filter) AddHash(h string) {
+	n := uint64(len(f.bits)) * 64
+	for _, i := range f.indices(h, n) {
+		f.bits[i/64] |= 1 << (i % 64)
+	}
+}
+
+// HasHash reports whether a keyed hash is probably in the filter.
+func (f *Filter) HasHash(h string) bool {
+	n := uint64(len(f.bits)) * 64
+	for _, i := range f.indices(h, n) {
+		if f.bits[i/64]&(1<<(i%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// indices returns the k bit positions of h in a filter of n bits, by
+// double hashing.
+func (f *Filter) indices(h string, n uint64) []uint64 {
+	sum := sha256.Sum256([]byte(h))
+	var (
+		a   = binary.BigEndian.Uint64(sum[:8])
+		b   = binary.BigEndian.Uint64(sum[8:16]) | 1
+		out = make([]uint64, f.k)
+	)
+	for i := range out {
+		out[i] = (a + uint64(i)*b) % n
+	}
+	return out
+}
+
+// WriteTo writes the filter to w in a compact binary format.
+func (f *Filter) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	hdr := struct {
+		Magic [4]byte
+		Level uint8
+		K     uint32
+		N     uint64
+	}{magic, uint8(f.Level), f.k, uint64(len(f.bits))}
+
+	if err := binary.Write(bw, binary.BigEndian, hdr); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(bw, binary.BigEndian, f.bits); err != nil {
+		return 0, err
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	return int64(binary.Size(hdr) + 8*len(f.bits)), nil
+}
+
+// Read reads a filter written by WriteTo.
+func Read(r io.Reader) (*Filter, error) {
+	var hdr struct {
+		Magic [4]byte
+		Level uint8
+		K     uint32
+		N     uint64
+	}
+	if err := binary.Read(r, binary.BigEndian, &hdr); err != nil {
+		return nil, err
+	}
+	if hdr.Magic != magic {
+		return nil, errors.New("psi: not a filter")
+	}
+	if hdr.Level > uint8(taphone.Key2) || hdr.K == 0 || hdr.K > MaxHashes || hdr.N == 0 || hdr.N > MaxFilterWords {
+		return nil, errors.New("psi: invalid filter header")
+	}
+
+	f := &Filter{
+		Level: taphone.KeyLevel(hdr.Level),
+		k:     hdr.K,
+	}
+	for n := int(hdr.N); len(f.bits) < n; {
+		chunk := make([]uint64, readChunk)
+		if rest := n - len(f.bits); rest < readChunk {
+			chunk = chunk[:rest]
+		}
+		if err := binary.Read(r, binary.BigEndian, chunk); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, fmt.Errorf("psi: reading filter: %w", err)
+		}
+		f.bits = append(f.bits, chunk...)
+	}
+	return f, nil
+}
+
+// hash returns the keyed hash of word at the given level.
+func hash(enc *taphone.TAphone, secret []byte, level taphone.KeyLevel, word string) string {
+	var h [3]string
+	h[taphone.Key0], h[taphone.Key1], h[taphone.Key2] = enc.EncodeHMAC(word, secret)
+	return h[level]
+}
@@ -0,0 +1,149 @@
+package psi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+var secret = []byte("shared secret")
+
+func TestCheck(t *testing.T) {
+	var (
+		enc   = taphone.New()
+		words = []string{"முருகன்", "கண்ணன்", "லட்சுமி"}
+		f     = Export(enc, secret, taphone.Key0, words, 0)
+	)
+	cases := []struct {
+		name   string
+		word   string
+		secret []byte
+		want   bool
+	}{
+		{"exported word", "முருகன்", secret, true},
+		{"soundalike", "கன்னன்", secret, true},
+		{"other word", "செல்வம்", secret, false},
+		{"no key", "hello", secret, false},
+		{"other secret", "முருகன்", []byte("other"), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := f.Check(enc, c.secret, c.word); got != c.want {
+				t.Errorf("Check(%q) = %v, want %v", c.word, got, c.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		name   string
+		n      int
+		fpRate float64
+		k      uint32
+		words  int
+	}{
+		{"default rate", 1000, 0, 10, 225},
+		{"one percent", 1000, 0.01, 7, 150},
+		{"invalid rate", 1000, 1.5, 10, 225},
+		{"no items", 0, 0.01, 7, 1},
+	}
+	for _, c := range cases {
+		f := New(taphone.Key0, c.n, c.fpRate)
+		if f.k != c.k || len(f.bits) != c.words {
+			t.Errorf("%s: New(%d, %v) has %d hashes and %d words, want %d and %d",
+				c.name, c.n, c.fpRate, f.k, len(f.bits), c.k, c.words)
+		}
+	}
+}
+
+func TestFalsePositiveRate(t *testing.T) {
+	enc := taphone.New()
+	f := New(taphone.Key2, 1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		h, _, _ := enc.EncodeHMAC("கடல்", []byte{byte(i), byte(i >> 8)})
+		f.AddHash(h)
+	}
+	fp := 0
+	for i := 0; i < 10000; i++ {
+		h, _, _ := enc.EncodeHMAC("மலை", []byte{byte(i), byte(i >> 8), 1})
+		if f.HasHash(h) {
+			fp++
+		}
+	}
+	if fp > 300 {
+		t.Errorf("%d false positives in 10000, want about 100", fp)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	enc := taphone.New()
+	f := Export(enc, secret, taphone.Key2, []string{"முருகன்", "கண்ணன்"}, 0.01)
+
+	var buf bytes.Buffer
+	n, err := f.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo() = %d, wrote %d bytes", n, buf.Len())
+	}
+
+	g, err := Read(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Level != f.Level || g.k != f.k || len(g.bits) != len(f.bits) {
+		t.Fatalf("Read() = level %d, %d hashes, %d words, want %d, %d, %d",
+			g.Level, g.k, len(g.bits), f.Level, f.k, len(f.bits))
+	}
+	if !g.Check(enc, secret, "முருகன்") || g.Check(enc, secret, "செல்வம்") {
+		t.Error("Read() filter doesn't match like the original")
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	header := func(level uint8, k uint32, n uint64) []byte {
+		var b bytes.Buffer
+		b.Write(magic[:])
+		binary.Write(&b, binary.BigEndian, struct {
+			Level uint8
+			K     uint32
+			N     uint64
+		}{level, k, n})
+		return b.Bytes()
+	}
+
+	cases := []struct {
+		name  string
+		input []byte
+		err   error
+		msg   string
+	}{
+		{"empty", nil, io.EOF, ""},
+		{"not a filter", []byte("XXXXXXXXXXXXXXXXX"), nil, "not a filter"},
+		{"invalid level", header(3, 1, 1), nil, "invalid filter header"},
+		{"no hashes", header(0, 0, 1), nil, "invalid filter header"},
+		{"too many hashes", header(0, MaxHashes+1, 1), nil, "invalid filter header"},
+		{"too large", header(0, 1, MaxFilterWords+1), nil, "invalid filter header"},
+		{"truncated", header(0, 1, MaxFilterWords), io.ErrUnexpectedEOF, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(c.input))
+			switch {
+			case err == nil:
+				t.Error("Read() succeeded")
+			case c.err != nil && !errors.Is(err, c.err):
+				t.Errorf("Read() error = %v, want %v", err, c.err)
+			case c.msg != "" && !strings.Contains(err.Error(), c.msg):
+				t.Errorf("Read() error = %v, want %q", err, c.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package taphone
+
+import (
+	"regexp"
+	"strings"
+)
+
+// romanConsonants maps Latin (Thanglish) consonant spellings to the key0
+// codes of the Tamil consonants they are commonly used for. Spellings of
+// Grantha consonants that the encoder doesn't code map to nothing.
+var romanConsonants = map[string]string{
+	"ndr": "NR", "tr": "RR",
+
+	"zh": "Z", "th": "T", "dh": "T", "ch": "C", "sh": "", "kh": "K",
+	"gh": "K", "ph": "P", "bh": "P", "ng": "NG", "nj": "NJ", "gn": "NJ",
+
+	"k": "K", "g": "K", "q": "K", "c": "C", "s": "C", "j": "", "t": "T",
+	"d": "T", "n": "N", "p": "P", "b": "P", "f": "P", "m": "M", "y": "Y",
+	"r": "R", "l": "L", "v": "V", "w": "V", "z": "Z", "h": "",
+}
+
+// romanVowels maps Latin vowel spellings to the key0 code of the Tamil
+// vowel sign they denote. Only the i-signs survive in key0.
+var romanVowels = map[string]string{
+	"aa": "", "ai": "", "au": "", "ee": "3", "ii": "3", "oo": "", "uu": "",
+	"ei": "", "a": "", "i": "3", "u": "", "e": "", "o": "",
+}
+
+// romanInitialVowels maps Latin vowel spellings at the start of a word to
+// the key0 code of the Tamil vowel they denote.
+var romanInitialVowels = map[string]string{
+	"aa": "A", "ai": "AI", "au": "O", "ee": "I", "ii": "I", "oo": "U",
+	"uu": "U", "ei": "AI", "ae": "E", "a": "A", "i": "I", "u": "U", "e": "E",
+	"o": "O",
+}
+
+// romanVariants rewrite common variant Latin spellings of Tamil names to
+// the spellings the tables above expect, so that they key alike. They are
+// applied in order to each lowercased word.
+var romanVariants = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// Karthick → Karthik, Laxmi → Lakshmi.
+	{regexp.MustCompile(`ck`), "k"},
+	{regexp.MustCompile(`x`), "ksh"},
+
+	// A hard c, common in Sri Lankan and Pondicherry spellings:
+	// Coomaraswamy → Kumaraswamy, Canagaratnam → Kanagaratnam.
+	{regexp.MustCompile(`c([aou])`), "k$1"},
+
+	// Shanmugam, Sharavanan: an initial sh is ச.
+	{regexp.MustCompile(`\bsh`), "s"},
+
+	// Ramaiah → Ramaiya (ராமையா).
+	{regexp.MustCompile(`aiah\b`), "aiya"},
+
+	// Ponniah → Ponnaiya (பொன்னையா).
+	{regexp.MustCompile(`([^a])iy?ah?\b`), "${1}aiya"},
+
+	// த is geminated after ர்: Karthik, Moorthy (கார்த்திக், மூர்த்தி).
+	{regexp.MustCompile(`rth`), "rtth"},
+
+	// Kandasamy → Kandasami (கந்தசாமி).
+	{regexp.MustCompile(`([^aeiou])y\b`), "${1}i"},
+}
+
+// EncodeRoman encodes a Latin transliteration of a Tamil word (Thanglish)
+// to a key that is comparable with the key0 of the Tamil word, with the
+// encoder's key options (length buckets, maximum length, verbose codes,
+// lowercase and loan folding) applied; n-gram fallbacks are not. Common
+// variant spellings of names (Karthik, Karthick, Kaarthik; Ponniah,
+// Ponnaiya) key alike. The key of a partially typed word is usually a
+// prefix of the key of the complete word, but not where typing the rest
+// completes a variant spelling at the end of the word (Ponni, Ponniah)
+// or changes its length bucket.
+func (k *TAphone) EncodeRoman(input string) string {
+	var (
+		s   = strings.ToLower(input)
+		out strings.Builder
+	)
+	for _, v := range romanVariants {
+		s = v.re.ReplaceAllString(s, v.repl)
+	}
+
+	// Keep latin letters only.
+	s = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r
+		}
+		return -1
+	}, s)
+
+	consonants := romanConsonants
+	if k.loanSounds {
+		consonants = romanLoanConsonants
+	}
+
+	for i := 0; i < len(s); {
+		if i == 0 {
+			if code, n := matchRoman(s, i, romanInitialVowels); n > 0 {
+				out.WriteString(code)
+				i += n
+				continue
+			}
+		}
+
+		if code, n := matchRoman(s, i, consonants); n > 0 {
+			// ங and ஞ are almost always followed by their homorganic
+			// stops (ங்க, ஞ்ச) in the middle of words.
+			if i+n < len(s) && isRomanVowel(s[i+n]) {
+				switch code {
+				case "NG":
+					code = "NGK"
+				case "NJ":
+					code = "NJC"
+				}
+			}
+			out.WriteString(code)
+			i += n
+			continue
+		}
+
+		if code, n := matchRoman(s, i, romanVowels); n > 0 {
+			out.WriteString(code)
+			i += n
+			continue
+		}
+		i++
+	}
+	return k.derive(input, out.String(), false)[Key0]
+}
+
+// matchRoman returns the code for the longest spelling in table that
+// occurs in s at i, and the length of the spelling.
+func matchRoman(s string, i int, table map[string]string) (string, int) {
+	for n := 3; n > 0; n-- {
+		if i+n > len(s) {
+			continue
+		}
+		if code, ok := table[s[i:i+n]]; ok {
+			return code, n
+		}
+	}
+	return "", 0
+}
+
+func isRomanVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) > -1
+}
@@ -0,0 +1,81 @@
+package taphone
+
+import "testing"
+
+func TestEncodeRoman(t *testing.T) {
+	cases := []struct {
+		roman, tamil string
+	}{
+		{"vanakkam", "வணக்கம்"},
+		{"thamizh", "தமிழ்"},
+		{"Karthik", "கார்த்திக்"},
+		{"Karthick", "கார்த்திக்"},
+		{"Kandasamy", "கந்தசாமி"},
+		{"Ponniah", "பொன்னையா"},
+		{"Coomaraswamy", "குமாரசுவாமி"},
+		{"Murugan", "முருகன்"},
+	}
+
+	opts := []struct {
+		name string
+		opts []Option
+	}{
+		{"default", nil},
+		{"length buckets", []Option{WithLengthBuckets()}},
+		{"verbose", []Option{WithVerboseCodes()}},
+		{"lowercase", []Option{WithLowercase()}},
+		{"max length", []Option{WithMaxLength(2)}},
+	}
+	for _, o := range opts {
+		k := New(o.opts...)
+		for _, c := range cases {
+			if got, want := k.EncodeRoman(c.roman), k.EncodeLevel(c.tamil, Key0); got != want {
+				t.Errorf("%s: EncodeRoman(%q) = %q, want %q as for %s", o.name, c.roman, got, want, c.tamil)
+			}
+		}
+	}
+}
+
+func TestEncodeRomanPrefix(t *testing.T) {
+	k := New()
+	cases := []struct {
+		partial, full string
+	}{
+		{"van", "vanakkam"},
+		{"tham", "thamizh"},
+		{"Kart", "Karthick"},
+		{"Kandas", "Kandasamy"},
+	}
+	for _, c := range cases {
+		p, f := k.EncodeRoman(c.partial), k.EncodeRoman(c.full)
+		if len(p) > len(f) || f[:len(p)] != p {
+			t.Errorf("EncodeRoman(%q) = %q, not a prefix of %q for %q", c.partial, p, f, c.full)
+		}
+	}
+}
+
+func TestEncodeRomanVariants(t *testing.T) {
+	cases := [][]string{
+		{"Karthik", "Karthick", "Kaarthik"},
+		{"Saravanan", "Sarvanan", "Sharavanan"},
+		{"Lakshmi", "Laxmi"},
+		{"Kanagaratnam", "Canagaratnam"},
+		{"Kumaraswamy", "Coomaraswamy", "Kumaraswami"},
+		{"Moorthy", "Moorthi", "Murthy"},
+		{"Ponnaiya", "Ponniah", "Ponnaiah"},
+		{"Ramaiya", "Ramaiah", "Ramiah"},
+		{"Kandasamy", "Kandasami", "Kanthasamy"},
+	}
+	k := New()
+	for _, c := range cases {
+		want := k.EncodeRoman(c[0])
+		for _, v := range c[1:] {
+			if got := k.EncodeRoman(v); got != want {
+				t.Errorf("EncodeRoman(%q) = %q, want %q as for %s", v, got, want, c[0])
+			}
+		}
+	}
+	if got, want := k.EncodeRoman("Ramaiah"), k.EncodeLevel("ராமையா", Key0); got != want {
+		t.Errorf("EncodeRoman(Ramaiah) = %q, want %q as for ராமையா", got, want)
+	}
+}
@@ -0,0 +1,24 @@
+package taphone
+
+import "strings"
+
+// latinPhones maps the IPA phonemes produced by Phonemes to the Latin
+// spellings commonly used for them in Tamil names and titles. Longer
+// phonemes are listed first so that they are matched first.
+var latinPhones = strings.NewReplacer(
+	"tʃtʃ", "cch", "t̪t̪", "tth", "ŋɡ", "ng", "ɲdʒ", "nj",
+	"aː", "aa", "iː", "ee", "uː", "oo", "eː", "e", "oː", "o", "ai̯", "ai",
+	"au̯", "au", "tʃ", "ch", "dʒ", "j", "t̪", "th", "d̪", "dh", "n̪", "n",
+	"ð", "dh", "ʈ", "t", "ɖ", "d", "ɡ", "g", "ɣ", "g", "ŋ", "ng", "ɲ", "nj",
+	"ɳ", "n", "ɾ", "r", "ʋ", "v", "ɻ", "zh", "ɭ", "l", "ʂ", "sh", "ʃ", "sh",
+	"ɯ", "u", "j", "y",
+)
+
+// Romanize transliterates Tamil text to plain ASCII Latin letters as it
+// is pronounced, in the informal spelling commonly used for Tamil names
+// (வணக்கம் → vanakkam, முருகன் → murugan, தமிழ் → thamizh). It is lossy
+// and meant for display, URLs and the like; use EncodeRoman to match
+// Latin spellings against Tamil words.
+func Romanize(text string) string {
+	return latinPhones.Replace(Phonemes(text, IPA))
+}
@@ -0,0 +1,28 @@
+package taphone
+
+import "testing"
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"வணக்கம்", "vanakkam"},
+		{"முருகன்", "murugan"},
+		{"தமிழ்", "thamizh"},
+		{"பச்சை", "pacchai"},
+		{"அம்மா", "ammaa"},
+		{"ஜெயா", "jeyaa"},
+		{"பெரியது", "periyadhu"},
+		{"ஞானம்", "njaanam"},
+		{"மஞ்சள்", "manjal"},
+		{"கௌரி", "kauri"},
+		{"வணக்கம் நண்பர்களே", "vanakkam nanbargale"},
+		{"hello", ""},
+	}
+	for _, c := range cases {
+		if got := Romanize(c.input); got != c.want {
+			t.Errorf("Romanize(%q) = %q, want %q", c.input, got, c.want)
+		}
+	}
+}
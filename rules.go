@@ -0,0 +1,83 @@
+package taphone
+
+import "sort"
+
+// Rules is the effective configuration of an encoder: its code tables
+// and the preprocessing and key options applied on top of them.
+type Rules struct {
+	// Vowels, Consonants and Modifiers map Tamil glyphs to their codes.
+	// Loans maps the Grantha letters and loan sound digraphs to their
+	// codes if loan sounds are coded, and is nil otherwise.
+	Vowels     map[string]string
+	Consonants map[string]string
+	Modifiers  map[string]string
+	Loans      map[string]string
+
+	// Filters are the names of the preprocessing filters in the order
+	// they are applied, such as "profile:name", "expansions" or
+	// "deobfuscate".
+	Filters []string
+
+	// Profiles are the configured profiles and Expansions the merged
+	// abbreviation expansions.
+	Profiles   []Profile
+	Expansions map[string]string
+
+	// Stopwords are the words dropped from phrases, sorted.
+	Stopwords []string
+
+	// Key options. MaxLength is the maximum number of codes in a key, 0
+	// if unlimited.
+	PhraseSeparator string
+	Disambiguation  bool
+	LengthBuckets   bool
+	NgramFallback   int
+	Lowercase       bool
+	VerboseCodes    bool
+	LoanSounds      bool
+	LoanFolding     bool
+	MaxLength       int
+}
+
+// Rules returns the encoder's effective rules. The returned values are
+// copies and changing them doesn't affect the encoder.
+func (k *TAphone) Rules() Rules {
+	r := Rules{
+		Vowels:          copyMap(vowels),
+		Consonants:      copyMap(consonants),
+		Modifiers:       copyMap(modifiers),
+		Filters:         append([]string(nil), k.filterNames...),
+		Profiles:        append([]Profile(nil), k.profiles...),
+		Expansions:      copyMap(k.expansions),
+		PhraseSeparator: k.phraseSep,
+		Disambiguation:  k.disambiguate,
+		LengthBuckets:   k.lengthBuckets,
+		NgramFallback:   k.ngram,
+		Lowercase:       k.lowercase,
+		VerboseCodes:    k.verbose,
+		LoanSounds:      k.loanSounds,
+		LoanFolding:     k.loanFold,
+	}
+	if k.maxLen > 0 {
+		r.MaxLength = k.maxLen
+	}
+	if k.loanSounds {
+		r.Loans = make(map[string]string, len(loanLetters))
+		for _, l := range loanLetters {
+			r.Loans[l[0]] = l[1]
+		}
+	}
+	for w := range k.stopwords {
+		r.Stopwords = append(r.Stopwords, w)
+	}
+	sort.Strings(r.Stopwords)
+	return r
+}
+
+func copyMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	cases := []struct {
+		name   string
+		opts   []Option
+		check  func(Rules) bool
+		expect string
+	}{
+		{"defaults", nil, func(r Rules) bool {
+			return r.Consonants["க"] == "K" && r.Vowels["ஐ"] == "AI" && r.Modifiers["ு"] == "4" &&
+				r.Loans == nil && r.MaxLength == 0 && r.PhraseSeparator == SeparatorSpace
+		}, "default tables without loans"},
+		{"loan sounds", []Option{WithLoanSounds()}, func(r Rules) bool {
+			return r.LoanSounds && reflect.DeepEqual(r.Loans, map[string]string{
+				"ஃப": "F", "ஃஜ": "Q", "ஜ": "D", "ஷ": "X", "ஸ": "S", "ஶ": "S", "ஹ": "H",
+			})
+		}, "the loan table"},
+		{"loan folding", []Option{WithLoanFolding()}, func(r Rules) bool {
+			return r.LoanSounds && r.LoanFolding && r.Loans["ஜ"] == "D"
+		}, "the loan table with folding"},
+		{"key options", []Option{WithMaxLength(4), WithLengthBuckets(), WithVerboseCodes()}, func(r Rules) bool {
+			return r.MaxLength == 4 && r.LengthBuckets && r.VerboseCodes && !r.Lowercase
+		}, "max length, length buckets and verbose codes"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if r := New(c.opts...).Rules(); !c.check(r) {
+				t.Errorf("Rules() = %+v, want %s", r, c.expect)
+			}
+		})
+	}
+}
+
+func TestRulesCopies(t *testing.T) {
+	r := New(WithLoanSounds()).Rules()
+	r.Consonants["க"] = "X"
+	r.Loans["ஜ"] = "X"
+	r = New(WithLoanSounds()).Rules()
+	if r.Consonants["க"] != "K" || r.Loans["ஜ"] != "D" {
+		t.Errorf("changing Rules changed the tables: %v %v", r.Consonants["க"], r.Loans["ஜ"])
+	}
+}
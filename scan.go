@@ -0,0 +1,49 @@
+package taphone
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// ScanTamilWords is a split function for a bufio.Scanner that returns
+// each run of Tamil letters, with their combining marks and joiners, as
+// a token. Everything else, including digits, punctuation and words in
+// other scripts, is skipped.
+//
+//	sc := bufio.NewScanner(r)
+//	sc.Split(taphone.ScanTamilWords)
+//	for sc.Scan() {
+//		fmt.Println(k.Encode(sc.Text()))
+//	}
+func ScanTamilWords(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	// Skip everything up to the first Tamil letter.
+	start := 0
+	for start < len(data) {
+		if !atEOF && !utf8.FullRune(data[start:]) {
+			return start, nil, nil
+		}
+		r, w := utf8.DecodeRune(data[start:])
+		if k, ok := runeKind(r); ok && k == TokenTamil {
+			break
+		}
+		start += w
+	}
+
+	// Scan until the end of the word.
+	for i := start; i < len(data); {
+		if !atEOF && !utf8.FullRune(data[i:]) {
+			return start, nil, nil
+		}
+		r, w := utf8.DecodeRune(data[i:])
+		if k, ok := runeKind(r); !ok || (k != TokenTamil && !unicode.Is(unicode.M, r) && r != zwnj && r != zwj) {
+			return i + w, data[start:i], nil
+		}
+		i += w
+	}
+
+	// The word runs to the end of the input.
+	if atEOF && len(data) > start {
+		return len(data), data[start:], nil
+	}
+	return start, nil, nil
+}
@@ -0,0 +1,45 @@
+package taphone
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestScanTamilWords(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"words and punctuation", "வணக்கம், உலகம்!", []string{"வணக்கம்", "உலகம்"}},
+		{"other scripts and digits", "Tamil தமிழ் 2024 ௨௦ हिन्दी மொழி", []string{"தமிழ்", "மொழி"}},
+		{"markup", "<p>கடல்</p><b>அலை</b>", []string{"கடல்", "அலை"}},
+		{"joiners", "க்‍ஷ", []string{"க்‍ஷ"}},
+		{"word at the end", "கடல்", []string{"கடல்"}},
+		{"no words", "hello, 123", nil},
+		{"empty", "", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// One byte reads split runes across calls.
+			for _, r := range []io.Reader{strings.NewReader(c.input), iotest.OneByteReader(strings.NewReader(c.input))} {
+				sc := bufio.NewScanner(r)
+				sc.Split(ScanTamilWords)
+				var got []string
+				for sc.Scan() {
+					got = append(got, sc.Text())
+				}
+				if err := sc.Err(); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got, c.want) {
+					t.Errorf("ScanTamilWords(%q) = %q, want %q", c.input, got, c.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package taphone
+
+import "strings"
+
+// Fuzziness is how loosely Search matches words, from 0 (exact) to 3
+// (loosest), so that applications can offer a single setting instead of
+// choosing key levels.
+type Fuzziness int
+
+// Fuzziness levels.
+const (
+	// FuzzyExact matches the word itself only.
+	FuzzyExact Fuzziness = iota
+
+	// FuzzyKey2 matches words that share key2: spellings that sound the
+	// same.
+	FuzzyKey2
+
+	// FuzzyKey1 matches words that share key1, ignoring vowel length
+	// and other modifiers.
+	FuzzyKey1
+
+	// FuzzyKey0 matches words that share key0, ignoring hard sounds too,
+	// and words whose key0 is a single code edit away from it, counting
+	// a code such as NG with its modifier digits as one. If a keyboard
+	// layout is set on the dictionary, adjacent-key typos are matched
+	// too. Finding the keys an edit away scans the whole key0 index
+	// under the dictionary's read lock on every search, which takes time
+	// linear in the number of distinct key0 keys and holds off writers
+	// meanwhile.
+	FuzzyKey0
+)
+
+// Search returns up to n dictionary words, or all if n < 1, that match
+// word at the given fuzziness, best first. Values beyond the range of
+// Fuzziness are clamped to it. Matches are ranked like those of Suggest,
+// and words that only match by key0 edit distance are ranked below those
+// that share key0.
+func (d *Dictionary) Search(word string, fuzziness Fuzziness, n int) []Suggestion {
+	switch {
+	case fuzziness < FuzzyExact:
+		fuzziness = FuzzyExact
+	case fuzziness > FuzzyKey0:
+		fuzziness = FuzzyKey0
+	}
+
+	if fuzziness == FuzzyExact {
+		if d.Contains(word) {
+			return []Suggestion{{Word: word, Score: 1}}
+		}
+		return nil
+	}
+
+	keys := d.enc.keys(word)
+	if keys[Key0] == "" {
+		return nil
+	}
+
+	// The narrowest key level that is matched.
+	lowest := Key2 - KeyLevel(fuzziness-FuzzyKey2)
+
+	d.mu.RLock()
+	var (
+		layout = d.layout
+		cands  = make(map[*entry]float64)
+	)
+	for l := lowest; l <= Key2; l++ {
+		for _, e := range d.index[l][keys[l]] {
+			cands[e] = levelWeights[l]
+		}
+	}
+	if fuzziness == FuzzyKey0 {
+		// Distance matches weigh half as much as key0 matches.
+		q := keyUnits(keys[Key0])
+		for key, entries := range d.index[Key0] {
+			// A code with its digits is at most three bytes long.
+			if key == keys[Key0] || absDiff(len(key), len(keys[Key0])) > 3 {
+				continue
+			}
+			if k := keyUnits(key); editDistance(len(q), len(k), func(i, j int) bool { return q[i] == k[j] }) > 1 {
+				continue
+			}
+			for _, e := range entries {
+				if _, ok := cands[e]; !ok {
+					cands[e] = levelWeights[Key0] / 2
+				}
+			}
+		}
+		if layout != nil {
+			for _, v := range layout.typoVariants(word) {
+				if e, ok := d.words[v]; ok {
+					if _, ok := cands[e]; !ok {
+						cands[e] = levelWeights[Key0]
+					}
+				}
+			}
+		}
+	}
+	d.mu.RUnlock()
+
+	return rankCandidates(word, cands, layout, n)
+}
+
+// keyUnits splits a key into its codes, each with the modifier digits
+// that follow it, for counting edits between keys. A length bucket
+// prefix is a unit of its own. Keys that can't be parsed, such as
+// verbose keys, are split into runes.
+func keyUnits(key string) []string {
+	var out []string
+	rest := strings.TrimLeft(key, "0123456789")
+	if rest != key {
+		out = append(out, key[:len(key)-len(rest)])
+	}
+
+	toks, ok := tokenizeKey(strings.ToUpper(rest))
+	if !ok {
+		for _, r := range rest {
+			out = append(out, string(r))
+		}
+		return out
+	}
+	for _, t := range toks {
+		out = append(out, t.code+t.mods)
+	}
+	return out
+}
+
+func absDiff(a, b int) int {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,61 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	d := NewDictionary(New())
+	for _, w := range []string{"கடல்", "கடல", "கடன்", "பங்கு", "பகு", "படம்", "பட்டம்", "மடம்"} {
+		d.Add(w, 1)
+	}
+
+	cases := []struct {
+		name  string
+		word  string
+		fuzzy Fuzziness
+		want  []string
+	}{
+		{"exact", "படம்", FuzzyExact, []string{"படம்"}},
+		{"exact misses", "கடள்", FuzzyExact, nil},
+		{"key2", "கடள்", FuzzyKey2, []string{"கடல", "கடல்"}},
+		{"key1", "கடள்", FuzzyKey1, []string{"கடல", "கடல்"}},
+		{"key0 edit", "கடள்", FuzzyKey0, []string{"கடல", "கடல்", "கடன்"}},
+		{"key0 edits count codes", "பகு", FuzzyKey0, []string{"பகு", "பங்கு"}},
+		{"key0 insertion and substitution", "படம்", FuzzyKey0, []string{"படம்", "பட்டம்", "மடம்"}},
+		{"clamped below", "படம்", -1, []string{"படம்"}},
+		{"clamped above", "பகு", FuzzyKey0 + 1, []string{"பகு", "பங்கு"}},
+		{"no key", "hello", FuzzyKey0, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			for _, s := range d.Search(c.word, c.fuzzy, 0) {
+				got = append(got, s.Word)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Search(%q, %d) = %q, want %q", c.word, c.fuzzy, got, c.want)
+			}
+		})
+	}
+}
+
+func TestKeyUnits(t *testing.T) {
+	cases := []struct {
+		key  string
+		want []string
+	}{
+		{"PNGK", []string{"P", "NG", "K"}},
+		{"K3TNGK", []string{"K3", "T", "NG", "K"}},
+		{"5VNKKM", []string{"5", "V", "N", "K", "K", "M"}},
+		{"aiNT", []string{"AI", "N", "T"}},
+		{"K-T", []string{"K", "-", "T"}},
+		{"", nil},
+	}
+	for _, c := range cases {
+		if got := keyUnits(c.key); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("keyUnits(%q) = %q, want %q", c.key, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package taphone
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// segMaxWord is the maximum length of a word in graphemes that the
+	// segmenter considers.
+	segMaxWord = 16
+
+	// segPhoneticPenalty is the log probability penalty for segments that
+	// only match a dictionary word phonetically.
+	segPhoneticPenalty = -3
+)
+
+// Graphemes splits s into grapheme clusters: a base character followed by
+// any combining marks (virama, vowel signs, length marks).
+func Graphemes(s string) []string {
+	var (
+		out   []string
+		start = -1
+	)
+	for i, r := range s {
+		if unicode.Is(unicode.M, r) && start > -1 {
+			continue
+		}
+		if start > -1 {
+			out = append(out, s[start:i])
+		}
+		start = i
+	}
+	if start > -1 {
+		out = append(out, s[start:])
+	}
+	return out
+}
+
+// Segment splits text that lacks spaces (social media text, OCR output)
+// into words. Each whitespace separated chunk of text is segmented at
+// grapheme boundaries into the most probable sequence of dictionary
+// words with a Viterbi search. Chunks that exactly match dictionary words
+// are preferred, followed by chunks that match dictionary words
+// phonetically (key2). Unknown chunks are penalised by their length.
+func (d *Dictionary) Segment(text string) []string {
+	var out []string
+	for _, chunk := range strings.Fields(text) {
+		out = append(out, d.segment(chunk)...)
+	}
+	return out
+}
+
+func (d *Dictionary) segment(chunk string) []string {
+	g := Graphemes(chunk)
+
+	d.mu.RLock()
+	total := float64(d.total + 1)
+	d.mu.RUnlock()
+
+	// best[i] is the score of the best segmentation of g[:i] and
+	// back[i] the start of its last word.
+	var (
+		best = make([]float64, len(g)+1)
+		back = make([]int, len(g)+1)
+	)
+	for i := 1; i <= len(g); i++ {
+		best[i] = math.Inf(-1)
+		for j := i - 1; j >= 0 && i-j <= segMaxWord; j-- {
+			s := best[j] + d.segmentScore(strings.Join(g[j:i], ""), i-j, total)
+			if s > best[i] {
+				best[i], back[i] = s, j
+			}
+		}
+	}
+
+	var out []string
+	for i := len(g); i > 0; i = back[i] {
+		out = append(out, strings.Join(g[back[i]:i], ""))
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// segmentScore returns the log probability of word, of n graphemes,
+// being a word in the text.
+func (d *Dictionary) segmentScore(word string, n int, total float64) float64 {
+	if f := d.Freq(word); f > 0 {
+		return math.Log(float64(f) / total)
+	}
+
+	key := d.enc.keys(word)[Key2]
+	d.mu.RLock()
+	var f int
+	for _, e := range d.index[Key2][key] {
+		if e.freq > f {
+			f = e.freq
+		}
+	}
+	d.mu.RUnlock()
+	if f > 0 && key != "" {
+		return math.Log(float64(f)/total) + segPhoneticPenalty
+	}
+
+	// Unknown words get exponentially less likely with length.
+	return math.Log(10/total) - float64(n)*math.Log(10)
+}
@@ -0,0 +1,56 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	cases := []struct {
+		input string
+		want  []string
+	}{
+		{"வணக்கம்", []string{"வ", "ண", "க்", "க", "ம்"}},
+		{"கொடு", []string{"கொ", "டு"}},
+		{"க்ஷேத்ரம்", []string{"க்", "ஷே", "த்", "ர", "ம்"}},
+		{"abc", []string{"a", "b", "c"}},
+		{"்க", []string{"்", "க"}},
+		{"", nil},
+	}
+	for _, c := range cases {
+		if got := Graphemes(c.input); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Graphemes(%q) = %q, want %q", c.input, got, c.want)
+		}
+	}
+}
+
+func TestSegment(t *testing.T) {
+	d := NewDictionary(New())
+	for w, f := range map[string]int{
+		"நான்": 50, "வந்தேன்": 20, "தமிழ்": 30, "மொழி": 20, "கடல்": 10, "அலை": 10, "கடலலை": 1,
+	} {
+		d.Add(w, f)
+	}
+
+	cases := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"two words", "நான்வந்தேன்", []string{"நான்", "வந்தேன்"}},
+		{"spaced chunks", "நான் தமிழ்மொழி", []string{"நான்", "தமிழ்", "மொழி"}},
+		{"phonetic match", "தமிழ்மொலி", []string{"தமிழ்", "மொலி"}},
+		{"whole word preferred", "கடலலை", []string{"கடலலை"}},
+		{"unknown run", "நான்xyzவந்தேன்", []string{"நான்xyz", "வந்தேன்"}},
+		{"unknown letter", "நான்வந்தேன்ஏ", []string{"நான்", "வந்தேன்", "ஏ"}},
+		{"latin", "abcd", []string{"abcd"}},
+		{"empty", " ", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := d.Segment(c.text); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Segment(%q) = %q, want %q", c.text, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package taphone
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sentenceAbbreviations are words that are commonly abbreviated with a
+// full stop and don't end a sentence.
+var sentenceAbbreviations = map[string]bool{
+	// Titles and honorifics.
+	"டா": true, "டாக்": true, "திரு": true, "திருமதி": true, "செல்வி": true,
+	"பேரா": true, "முனை": true, "மரு": true, "பொறி": true,
+
+	// Eras, references and units.
+	"கி.மு": true, "கி.பி": true, "எ.கா": true, "பக்": true, "கி.மீ": true,
+	"மீ": true, "கி": true, "செ.மீ": true, "மி.லி": true, "ரூ": true,
+
+	// Common English abbreviations in mixed text.
+	"dr": true, "mr": true, "mrs": true, "ms": true, "prof": true, "st": true,
+	"etc": true, "vs": true, "no": true, "rs": true,
+}
+
+// singleGraphemeWords are words of one grapheme that are not initials.
+var singleGraphemeWords = map[string]bool{
+	"நீ": true, "பூ": true, "தீ": true, "கை": true, "வா": true, "போ": true,
+	"தா": true, "ஈ": true, "ஆ": true, "ஓ": true, "பை": true, "மா": true,
+}
+
+// sentenceEnders are the punctuation marks that end a sentence.
+var sentenceEnders = map[rune]bool{
+	'.': true, '?': true, '!': true, '।': true, '॥': true, '…': true,
+}
+
+// sentenceClosers are marks that may follow a sentence ender and belong
+// to the sentence it ends.
+var sentenceClosers = map[rune]bool{
+	'"': true, '\'': true, '”': true, '’': true, ')': true, ']': true, '»': true,
+}
+
+// SplitSentences splits text into sentences. A sentence ends at a full
+// stop, question or exclamation mark or ellipsis that is followed by
+// whitespace or the end of the text, and at every danda. Full stops
+// after abbreviations (திரு., கி.மு.) and single letter initials (மு.க.)
+// don't end sentences. Sentences are returned with surrounding
+// whitespace trimmed.
+func SplitSentences(text string) []string {
+	var (
+		out   []string
+		start = 0
+	)
+	for i := 0; i < len(text); {
+		r, n := utf8.DecodeRuneInString(text[i:])
+		i += n
+		if !sentenceEnders[r] {
+			continue
+		}
+
+		// Absorb repeated enders and closing quotes/brackets.
+		end := i
+		for end < len(text) {
+			c, m := utf8.DecodeRuneInString(text[end:])
+			if !sentenceEnders[c] && !sentenceClosers[c] {
+				break
+			}
+			end += m
+		}
+
+		// Must be followed by whitespace or the end of the text, except
+		// for the unambiguous dandas.
+		if end < len(text) && r != '।' && r != '॥' {
+			if c, _ := utf8.DecodeRuneInString(text[end:]); !unicode.IsSpace(c) {
+				continue
+			}
+		}
+
+		if r == '.' && isAbbreviation(text[start:i-n]) {
+			continue
+		}
+
+		if s := strings.TrimSpace(text[start:end]); s != "" {
+			out = append(out, s)
+		}
+		start, i = end, end
+	}
+
+	if s := strings.TrimSpace(text[start:]); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
+
+// isAbbreviation reports whether the last word of s, which precedes a
+// full stop, is an abbreviation or an initial.
+func isAbbreviation(s string) bool {
+	f := strings.Fields(s)
+	if len(f) == 0 {
+		return false
+	}
+	w := strings.ToLower(strings.TrimLeft(f[len(f)-1], "(\"'“‘"))
+
+	if sentenceAbbreviations[w] {
+		return true
+	}
+	if singleGraphemeWords[w] {
+		return false
+	}
+
+	// Initials: one or more single graphemes separated by full stops.
+	for _, p := range strings.Split(w, ".") {
+		if len(Graphemes(p)) != 1 {
+			return false
+		}
+	}
+	return true
+}
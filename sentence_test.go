@@ -0,0 +1,34 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"full stops", "நான் வந்தேன். அவன் போனான்.", []string{"நான் வந்தேன்.", "அவன் போனான்."}},
+		{"question and exclamation", "எங்கே போனாய்? வா! சரி", []string{"எங்கே போனாய்?", "வா!", "சரி"}},
+		{"repeated enders and quotes", "\"என்ன?!\" என்றான்.", []string{"\"என்ன?!\"", "என்றான்."}},
+		{"ellipsis", "சரி… பார்க்கலாம்", []string{"சரி…", "பார்க்கலாம்"}},
+		{"danda without space", "ஒன்று।இரண்டு॥", []string{"ஒன்று।", "இரண்டு॥"}},
+		{"abbreviation", "திரு. முருகன் வந்தார். சரி.", []string{"திரு. முருகன் வந்தார்.", "சரி."}},
+		{"era", "கி.மு. 300 இல் நடந்தது. பின்னர்", []string{"கி.மு. 300 இல் நடந்தது.", "பின்னர்"}},
+		{"initials", "மு.க. ஸ்டாலின் பேசினார்.", []string{"மு.க. ஸ்டாலின் பேசினார்."}},
+		{"single grapheme word", "இங்கே வா. போ.", []string{"இங்கே வா.", "போ."}},
+		{"english abbreviation", "Dr. Raman came. Then", []string{"Dr. Raman came.", "Then"}},
+		{"decimal number", "விலை 3.50 ரூபாய்.", []string{"விலை 3.50 ரூபாய்."}},
+		{"whitespace", "  \n ", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := SplitSentences(c.text); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("SplitSentences(%q) = %q, want %q", c.text, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package taphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Similarity returns the phonetic similarity of two words between 0 and
+// 1. Words that share key2 score 1, key1 0.9 and key0 0.8. Other words
+// score by the edit similarity of their key0s, scaled to at most 0.7. A
+// word in Latin script (Thanglish) is compared with the other by its
+// EncodeRoman key against key0. Words without a key, such as numbers or
+// words in other scripts, score 1 only if they are equal and 0 otherwise.
+func (k *TAphone) Similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	var ka, kb [3]string
+	switch la, lb := isLatin(a), isLatin(b); {
+	case la && lb:
+		ka[Key0], kb[Key0] = k.EncodeRoman(a), k.EncodeRoman(b)
+	case la:
+		ka[Key0], kb = k.EncodeRoman(a), k.keys(b)
+	case lb:
+		ka, kb[Key0] = k.keys(a), k.EncodeRoman(b)
+	default:
+		ka, kb = k.keys(a), k.keys(b)
+	}
+	return keySimilarity(ka, kb)
+}
+
+// NameSimilarity returns the phonetic similarity of two multi-word names
+// between 0 and 1. Each word of the shorter name is paired with its most
+// similar word in the longer name, so that word order doesn't matter,
+// and the pair similarities are averaged over the longer name's words.
+func (k *TAphone) NameSimilarity(a, b string) float64 {
+	wa, wb := strings.Fields(a), strings.Fields(b)
+	if len(wa) > len(wb) {
+		wa, wb = wb, wa
+	}
+	if len(wa) == 0 {
+		if len(wb) == 0 {
+			return 1
+		}
+		return 0
+	}
+
+	var (
+		total float64
+		used  = make([]bool, len(wb))
+	)
+	for _, x := range wa {
+		best, bi := 0.0, -1
+		for j, y := range wb {
+			if used[j] {
+				continue
+			}
+			if s := k.Similarity(x, y); s > best {
+				best, bi = s, j
+			}
+		}
+		if bi > -1 {
+			used[bi] = true
+		}
+		total += best
+	}
+	return total / float64(len(wb))
+}
+
+// keySimilarity returns the similarity of two sets of keys. Keys that
+// are empty on either side have nothing to compare and score 0.
+func keySimilarity(a, b [3]string) float64 {
+	if a[Key0] == "" || b[Key0] == "" {
+		return 0
+	}
+
+	switch {
+	case a[Key2] != "" && a[Key2] == b[Key2]:
+		return 1
+	case a[Key1] != "" && a[Key1] == b[Key1]:
+		return 0.9
+	case a[Key0] == b[Key0]:
+		return 0.8
+	}
+	return 0.7 * similarity([]rune(a[Key0]), []rune(b[Key0]))
+}
+
+// isLatin reports whether s has Latin letters and no Tamil letters.
+func isLatin(s string) bool {
+	latin := false
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Tamil, r):
+			return false
+		case unicode.Is(unicode.Latin, r):
+			latin = true
+		}
+	}
+	return latin
+}
@@ -0,0 +1,139 @@
+package taphone
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Slugger generates URL safe slugs from Tamil (and Latin) titles, with
+// a numeric suffix added to slugs that it has generated before. It is
+// safe for concurrent use.
+type Slugger struct {
+	// Separator separates the words of a slug. It defaults to "-".
+	Separator string
+
+	// MaxLength is the maximum length of a slug in bytes, including any
+	// suffix, or 0 for no limit. Slugs are cut at a word boundary where
+	// possible.
+	MaxLength int
+
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+// Slug returns a URL safe slug of lowercase ASCII letters, digits and
+// hyphens for a title. Tamil words are romanized with Romanize, Latin
+// words and digits (including Tamil digits) are kept and everything else
+// separates words (தமிழ் இணைய மாநாடு 2024 → thamizh-inaiya-maanaadu-2024).
+func Slug(title string) string {
+	return (&Slugger{}).slug(title)
+}
+
+// Slug returns the slug of a title. A slug that has been returned before
+// is made unique by a numeric suffix (murugan, murugan-2, murugan-3). An
+// empty slug is returned for titles without letters or digits.
+func (s *Slugger) Slug(title string) string {
+	slug := s.slug(title)
+	if slug == "" {
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen == nil {
+		s.seen = make(map[string]int)
+	}
+
+	// seen counts the slugs returned for each base slug, and records
+	// suffixed slugs so that they aren't returned for another title.
+	out := slug
+	if n := s.seen[slug]; n > 0 {
+		for ; ; n++ {
+			sfx := s.separator() + strconv.Itoa(n+1)
+			out = s.truncate(slug, s.MaxLength-len(sfx)) + sfx
+			if s.seen[out] == 0 {
+				break
+			}
+		}
+		s.seen[slug] = n + 1
+	}
+	s.seen[out]++
+	return out
+}
+
+// slug returns the slug of a title without a collision suffix.
+func (s *Slugger) slug(title string) string {
+	var words []string
+	for _, w := range strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.M, r)
+	}) {
+		w = slugWord(w)
+		if w != "" {
+			words = append(words, w)
+		}
+	}
+	return s.truncate(strings.Join(words, s.separator()), s.MaxLength)
+}
+
+// truncate cuts a slug to at most n bytes at the last separator that
+// fits, or mid word if there is none. n < 1 means no limit.
+func (s *Slugger) truncate(slug string, n int) string {
+	if s.MaxLength < 1 || len(slug) <= n {
+		return slug
+	}
+	if n < 1 {
+		return ""
+	}
+
+	sep := s.separator()
+	end := n + len(sep)
+	if end > len(slug) {
+		end = len(slug)
+	}
+	if i := strings.LastIndex(slug[:end], sep); i > 0 && i <= n {
+		return slug[:i]
+	}
+	return slug[:n]
+}
+
+func (s *Slugger) separator() string {
+	if s.Separator == "" {
+		return "-"
+	}
+	return s.Separator
+}
+
+// slugWord returns the lowercase ASCII spelling of a word, romanizing
+// runs of Tamil letters and converting Tamil digits.
+func slugWord(w string) string {
+	var (
+		b     strings.Builder
+		tamil strings.Builder
+	)
+	flush := func() {
+		if tamil.Len() > 0 {
+			b.WriteString(Romanize(tamil.String()))
+			tamil.Reset()
+		}
+	}
+	for _, r := range w {
+		switch {
+		case isTamilLetter(r):
+			tamil.WriteRune(r)
+			continue
+		case r >= '௦' && r <= '௯':
+			r = '0' + r - '௦'
+		case r >= 'A' && r <= 'Z':
+			r = unicode.ToLower(r)
+		case r > unicode.MaxASCII:
+			flush()
+			continue
+		}
+		flush()
+		b.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
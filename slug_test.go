@@ -0,0 +1,87 @@
+package taphone
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"தமிழ் இணைய மாநாடு 2024", "thamizh-inaiya-maanaadu-2024"},
+		{"Chennai மழை!", "chennai-mazhai"},
+		{"௨௦௨௪", "2024"},
+		{"அம்மா's கடை", "ammaa-s-kadai"},
+		{"Café முருகன்", "caf-murugan"},
+		{"!!!", ""},
+		{"", ""},
+	}
+	for _, c := range cases {
+		if got := Slug(c.input); got != c.want {
+			t.Errorf("Slug(%q) = %q, want %q", c.input, got, c.want)
+		}
+	}
+}
+
+func TestSlugger(t *testing.T) {
+	cases := []struct {
+		name   string
+		s      *Slugger
+		titles []string
+		want   []string
+	}{
+		{"collisions", &Slugger{}, []string{"முருகன்", "முருகன்", "Murugan", "முருகன்-2", "முருகன்"},
+			[]string{"murugan", "murugan-2", "murugan-3", "murugan-2-2", "murugan-4"}},
+		{"suffix taken by a title", &Slugger{}, []string{"முருகன் 2", "முருகன்", "முருகன்"},
+			[]string{"murugan-2", "murugan", "murugan-3"}},
+		{"separator", &Slugger{Separator: "_"}, []string{"வணக்கம் நண்பர்", "வணக்கம் நண்பர்"},
+			[]string{"vanakkam_nanbar", "vanakkam_nanbar_2"}},
+		{"max length at a word", &Slugger{MaxLength: 12}, []string{"வணக்கம் நண்பர்"},
+			[]string{"vanakkam"}},
+		{"max length mid word", &Slugger{MaxLength: 5}, []string{"வணக்கம்"},
+			[]string{"vanak"}},
+		{"max length with a suffix", &Slugger{MaxLength: 10}, []string{"வணக்கம் நண்பர்", "வணக்கம் நண்பர்"},
+			[]string{"vanakkam", "vanakkam-2"}},
+		{"empty", &Slugger{}, []string{"!!!", "!!!"}, []string{"", ""}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for i, title := range c.titles {
+				if got := c.s.Slug(title); got != c.want[i] {
+					t.Errorf("Slug(%q) #%d = %q, want %q", title, i, got, c.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSluggerConcurrent(t *testing.T) {
+	var (
+		s    Slugger
+		mu   sync.Mutex
+		seen = make(map[string]bool)
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slug := s.Slug("முருகன்")
+			mu.Lock()
+			defer mu.Unlock()
+			if seen[slug] {
+				t.Errorf("Slug() returned %q twice", slug)
+			}
+			seen[slug] = true
+		}()
+	}
+	wg.Wait()
+	for i := 2; i <= 50; i++ {
+		if !seen[fmt.Sprintf("murugan-%d", i)] {
+			t.Errorf("murugan-%d not returned", i)
+		}
+	}
+}
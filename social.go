@@ -0,0 +1,95 @@
+package taphone
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SocialAnalyzer returns an analyzer for social media text, where words
+// run together in hashtags and camel cased Thanglish (#VanakkamChennai).
+// It is the encoder's default analyzer (see Analyzer) with Latin tokens
+// kept and split with SplitCamelCase. Tamil tokens are keyed with the
+// encoder as usual, and Latin tokens with EncodeRoman, whose key is
+// comparable with key0 and is set as Keys[Key0] only.
+func (k *TAphone) SocialAnalyzer() *Analyzer {
+	return &Analyzer{
+		CharFilters:  append([]CharFilter(nil), k.filters...),
+		Tokenizer:    Tokenize,
+		TokenFilters: []TokenFilter{socialTokens, SplitCamelCase, stopwordFilter(k.stopwords)},
+		Emitter: func(t Token) [3]string {
+			if t.Kind == TokenLatin {
+				return [3]string{k.EncodeRoman(t.Base), "", ""}
+			}
+			return k.encode(t.Base)
+		},
+	}
+}
+
+// EncodeSocial analyzes social media text with SocialAnalyzer and returns
+// its terms in order (#VanakkamChennai → vanakkam, chennai).
+func (k *TAphone) EncodeSocial(text string) []Term {
+	return k.SocialAnalyzer().Analyze(text)
+}
+
+// SplitCamelCase is a token filter that splits Latin tokens at the
+// boundaries of camel case (VanakkamChennai → Vanakkam, Chennai;
+// TNElection → TN, Election) and sets the Base of each part to its
+// lowercase form.
+func SplitCamelCase(toks []Token) []Token {
+	out := make([]Token, 0, len(toks))
+	for _, t := range toks {
+		if t.Kind != TokenLatin {
+			out = append(out, t)
+			continue
+		}
+
+		start := 0
+		for i, r := range t.Text {
+			if i > start && isCamelBoundary(t.Text, i, r) {
+				out = append(out, camelPart(t, start, i))
+				start = i
+			}
+		}
+		out = append(out, camelPart(t, start, len(t.Text)))
+	}
+	return out
+}
+
+// isCamelBoundary reports whether a new word starts with r at byte i of
+// s: an uppercase letter after a lowercase one, or the last of a run of
+// uppercase letters that is followed by a lowercase letter.
+func isCamelBoundary(s string, i int, r rune) bool {
+	if !unicode.IsUpper(r) {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	if unicode.IsLower(prev) {
+		return true
+	}
+	next, _ := utf8.DecodeRuneInString(s[i+utf8.RuneLen(r):])
+	return unicode.IsUpper(prev) && unicode.IsLower(next)
+}
+
+// camelPart returns the part t.Text[start:end] of a token.
+func camelPart(t Token, start, end int) Token {
+	text := t.Text[start:end]
+	return Token{
+		Text:  text,
+		Kind:  t.Kind,
+		Start: t.Start + start,
+		End:   t.Start + end,
+		Base:  strings.ToLower(text),
+	}
+}
+
+// socialTokens is a token filter that keeps Tamil and Latin tokens.
+func socialTokens(toks []Token) []Token {
+	out := toks[:0:0]
+	for _, t := range toks {
+		if t.Kind == TokenTamil || t.Kind == TokenLatin {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEncodeSocial(t *testing.T) {
+	type term struct {
+		text, base, key0 string
+	}
+	k := New()
+	cases := []struct {
+		name  string
+		input string
+		want  []term
+	}{
+		{"hashtag", "#VanakkamChennai", []term{
+			{"Vanakkam", "vanakkam", k.EncodeRoman("vanakkam")},
+			{"Chennai", "chennai", k.EncodeRoman("chennai")},
+		}},
+		{"mixed script", "மழை in #Chennai 🙏", []term{
+			{"மழை", "மழை", "MZ"},
+			{"in", "in", k.EncodeRoman("in")},
+			{"Chennai", "chennai", k.EncodeRoman("chennai")},
+		}},
+		{"tamil stopwords", "இந்த மரம்", []term{{"மரம்", "மரம்", "MRM"}}},
+		{"roman matches tamil", "Vanakkam", []term{{"Vanakkam", "vanakkam", "VNKKM"}}},
+		{"no words", "#123 !!", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []term
+			for _, x := range k.EncodeSocial(c.input) {
+				got = append(got, term{x.Text, x.Base, x.Keys[Key0]})
+				if c.input[x.Start:x.End] != x.Text {
+					t.Errorf("term %q offsets [%d:%d] don't match", x.Text, x.Start, x.End)
+				}
+				if x.Kind == TokenLatin && (x.Keys[Key1] != "" || x.Keys[Key2] != "") {
+					t.Errorf("Latin term %q has keys %q, want key0 only", x.Text, x.Keys)
+				}
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("EncodeSocial(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSplitCamelCase(t *testing.T) {
+	cases := []struct {
+		input string
+		want  []string
+	}{
+		{"VanakkamChennai", []string{"vanakkam", "chennai"}},
+		{"TNElection", []string{"tn", "election"}},
+		{"iPhone", []string{"i", "phone"}},
+		{"TAMIL", []string{"tamil"}},
+		{"vanakkam", []string{"vanakkam"}},
+		{"மரம்", []string{"மரம்"}},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, t := range SplitCamelCase(Tokenize(c.input)) {
+			got = append(got, t.Base)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SplitCamelCase(%q) = %q, want %q", c.input, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package taphone
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stemMinGraphemes is the minimum length of a stem in graphemes. Suffixes
+// are not stripped if they would leave a shorter stem.
+const stemMinGraphemes = 2
+
+// stemSuffixes maps inflectional suffixes to the text that replaces them
+// when stripped, restoring the standalone spelling of the stem.
+var stemSuffixes = map[string]string{
+	// Plural with case markers.
+	"களுடைய": "", "களுக்கு": "", "களிடம்": "", "களிடமிருந்து": "",
+	"களால்": "", "களில்": "", "களிலிருந்து": "", "களை": "", "களின்": "",
+	"களோடு": "", "களுடன்": "", "கள்": "",
+
+	// Case markers after consonant final stems (அவன் + உக்கு).
+	"ுடைய": "்", "ுக்கு": "்", "ிடம்": "்", "ிடமிருந்து": "்", "ிலிருந்து": "்",
+	"ால்": "்", "ின்": "்", "ில்": "்", "ோடு": "்", "ுடன்": "்",
+
+	// Case markers after vowel final stems (அம்மா + வுக்கு).
+	"வுக்கு": "", "வுடைய": "", "விடம்": "", "வால்": "", "வின்": "", "வோடு": "",
+	"வுடன்": "",
+
+	// Oblique stems: மரம் → மரத்து-, வீடு → வீட்டு-, ஆறு → ஆற்று-.
+	"த்தில்": "ம்", "த்திற்கு": "ம்", "த்தை": "ம்", "த்தின்": "ம்",
+	"த்தால்": "ம்", "த்திலிருந்து": "ம்", "த்துடன்": "ம்",
+
+	"ட்டில்": "டு", "ட்டுக்கு": "டு", "ட்டை": "டு", "ட்டின்": "டு",
+	"ட்டிலிருந்து": "டு",
+
+	"ற்றில்": "று", "ற்றுக்கு": "று", "ற்றை": "று", "ற்றின்": "று",
+}
+
+// stemSuffixList holds the keys of stemSuffixes, longest first.
+var stemSuffixList = func() []string {
+	out := make([]string, 0, len(stemSuffixes))
+	for s := range stemSuffixes {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i]) != len(out[j]) {
+			return len(out[i]) > len(out[j])
+		}
+		return out[i] < out[j]
+	})
+	return out
+}()
+
+// Stem returns the stem of a Tamil word by stripping its longest known
+// inflectional suffix (plural and case markers) and restoring the
+// standalone spelling of the stem (மரத்தில் → மரம், மரங்கள் → மரம்,
+// அவனுக்கு → அவன்). It is a light, table based stemmer that strips at
+// most one suffix and leaves words it doesn't recognise unchanged.
+func Stem(word string) string {
+	for _, s := range stemSuffixList {
+		if !strings.HasSuffix(word, s) {
+			continue
+		}
+
+		// A suffix that starts with a vowel sign must follow a bare
+		// consonant.
+		stem := strings.TrimSuffix(word, s)
+		if r, _ := utf8.DecodeRuneInString(s); unicode.Is(unicode.M, r) {
+			last, _ := utf8.DecodeLastRuneInString(stem)
+			if unicode.Is(unicode.M, last) {
+				continue
+			}
+		}
+
+		stem += stemSuffixes[s]
+
+		// A final ம் becomes ங் before the plural: மரங்கள் → மரம்.
+		if strings.HasPrefix(s, "கள") && strings.HasSuffix(stem, "ங்") {
+			stem = strings.TrimSuffix(stem, "ங்") + "ம்"
+		}
+		if len(Graphemes(stem)) < stemMinGraphemes {
+			continue
+		}
+		return stem
+	}
+	return word
+}
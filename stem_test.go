@@ -0,0 +1,37 @@
+package taphone
+
+import "testing"
+
+func TestStem(t *testing.T) {
+	cases := []struct {
+		name string
+		word string
+		want string
+	}{
+		{"oblique ம்", "மரத்தில்", "மரம்"},
+		{"oblique ம் with ablative", "மரத்திலிருந்து", "மரம்"},
+		{"consonant final dative", "அவனுக்கு", "அவன்"},
+		{"consonant final locative", "கடலில்", "கடல்"},
+		{"vowel final dative", "அம்மாவுக்கு", "அம்மா"},
+		{"plural", "பறவைகள்", "பறவை"},
+		{"plural with case", "பறவைகளுக்கு", "பறவை"},
+		{"plural of a ம் final noun", "மரங்கள்", "மரம்"},
+		{"plural of a ம் final noun with case", "மரங்களில்", "மரம்"},
+		{"oblique டு", "வீட்டில்", "வீடு"},
+		{"oblique டு with ablative", "வீட்டிலிருந்து", "வீடு"},
+		{"oblique று", "ஆற்றில்", "ஆறு"},
+		{"genitive", "பாலின்", "பால்"},
+		{"stem too short", "கால்", "கால்"},
+		{"uninflected", "மரம்", "மரம்"},
+		{"unknown suffix", "பள்ளிக்கு", "பள்ளிக்கு"},
+		{"latin", "hello", "hello"},
+		{"empty", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Stem(c.word); got != c.want {
+				t.Errorf("Stem(%q) = %q, want %q", c.word, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package taphone
+
+import "strings"
+
+// verboseCodes and verboseMods are the mnemonics of codes and modifier
+// digits in verbose keys.
+var (
+	verboseCodes = map[string]string{
+		"T1": "TH", "R1": "RR", "N1": "NN", "Z": "ZH", "D": "J", "X": "SH", "Q": "Z",
+	}
+	verboseMods = map[rune]string{
+		'3': "i", '4': "u", '5': "e", '6': "ai", '7': "o", '8': "au", '9': "m",
+	}
+)
+
+// WithLowercase returns keys in lowercase, for storage layers that are
+// case insensitive.
+func WithLowercase() Option {
+	return func(k *TAphone) {
+		k.lowercase = true
+	}
+}
+
+// WithVerboseCodes returns keys in a readable style for debugging: codes
+// are separated by hyphens, hard sounds are spelt out and modifier
+// digits are replaced by the vowels they stand for (T1M3Z → TH-Mi-ZH).
+// Verbose keys can't be used with GenerateSpellings and the dictionary's
+// spelling generation, which parse the compact style.
+func WithVerboseCodes() Option {
+	return func(k *TAphone) {
+		k.verbose = true
+	}
+}
+
+// verboseKey renders a key in the verbose style. Keys that can't be
+// parsed are returned as is.
+func verboseKey(key string) string {
+	toks, ok := tokenizeKey(key)
+	if !ok {
+		return key
+	}
+
+	out := make([]string, len(toks))
+	for i, t := range toks {
+		c := t.code
+		if v, ok := verboseCodes[c]; ok {
+			c = v
+		}
+		for _, m := range t.mods {
+			if v, ok := verboseMods[m]; ok {
+				c += v
+			} else {
+				c += string(m)
+			}
+		}
+		out[i] = c
+	}
+	return strings.Join(out, "-")
+}
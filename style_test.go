@@ -0,0 +1,49 @@
+package taphone
+
+import "testing"
+
+func TestKeyStyles(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  []Option
+		input string
+		keys  [3]string
+	}{
+		{"verbose", []Option{WithVerboseCodes()}, "தமிழ்", [3]string{"T-Mi-ZH", "TH-Mi-ZH", "TH-Mi-ZH"}},
+		{"verbose vowels", []Option{WithVerboseCodes()}, "முருகன்", [3]string{"M-R-K-N", "M-R-K-NN", "Mu-Ru-K-NN"}},
+		{"verbose diphthongs", []Option{WithVerboseCodes()}, "பையன்", [3]string{"P-Y-N", "P-Y-NN", "Pai-Y-NN"}},
+		{"verbose au", []Option{WithVerboseCodes()}, "மௌனம்", [3]string{"M-N-M", "M-NN-M", "Mau-NN-M"}},
+		{"lowercase", []Option{WithLowercase()}, "தமிழ்", [3]string{"tm3z", "t1m3z", "t1m3z"}},
+		{"lowercase verbose", []Option{WithLowercase(), WithVerboseCodes()}, "கொடு", [3]string{"k-t", "k-t", "ko-tu"}},
+		{"with a length bucket", []Option{WithLowercase(), WithVerboseCodes(), WithLengthBuckets()}, "முருகன்",
+			[3]string{"5m-r-k-n", "m-r-k-nn", "mu-ru-k-nn"}},
+		{"no key", []Option{WithLowercase(), WithVerboseCodes()}, "hello", [3]string{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got [3]string
+			got[0], got[1], got[2] = New(c.opts...).Encode(c.input)
+			if got != c.keys {
+				t.Errorf("Encode(%q) = %q, want %q", c.input, got, c.keys)
+			}
+		})
+	}
+}
+
+func TestVerboseKey(t *testing.T) {
+	cases := []struct {
+		key  string
+		want string
+	}{
+		{"T1M3Z", "TH-Mi-ZH"},
+		{"R1N1", "RR-NN"},
+		{"K9", "Km"},
+		{"", ""},
+		{"??", "??"},
+	}
+	for _, c := range cases {
+		if got := verboseKey(c.key); got != c.want {
+			t.Errorf("verboseKey(%q) = %q, want %q", c.key, got, c.want)
+		}
+	}
+}
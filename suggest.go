@@ -0,0 +1,244 @@
+package taphone
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// levelWeights weigh candidates by the narrowest key level they share
+// with the query.
+var levelWeights = [3]float64{0.6, 0.8, 1}
+
+// Suggestion is a dictionary word suggested for a query.
+type Suggestion struct {
+	Word string
+	// Score is between 0 and 1, higher being a better match.
+	Score float64
+}
+
+// Suggest returns up to n dictionary words that sound like word, best
+// first. Candidates are words that share at least key0 with word, ranked
+// by the narrowest key level they share, their spelling similarity in
+// graphemes (see GraphemeDistance) and their frequency. If a keyboard
+// layout is set on the dictionary, words that are a single adjacent-key
+// typo away are also considered, and similarity accounts for key
+// adjacency. If word is in the dictionary, it is returned first with a
+// score of 1.
+func (d *Dictionary) Suggest(word string, n int) []Suggestion {
+	keys := d.enc.keys(word)
+	if keys[Key0] == "" {
+		return nil
+	}
+
+	d.mu.RLock()
+	var (
+		layout = d.layout
+		cands  = make(map[*entry]float64)
+	)
+	for l := Key0; l <= Key2; l++ {
+		for _, e := range d.index[l][keys[l]] {
+			cands[e] = levelWeights[l]
+		}
+	}
+	if layout != nil {
+		for _, v := range layout.typoVariants(word) {
+			if e, ok := d.words[v]; ok {
+				if _, ok := cands[e]; !ok {
+					cands[e] = levelWeights[Key0]
+				}
+			}
+		}
+	}
+	d.mu.RUnlock()
+
+	return rankCandidates(word, cands, layout, n)
+}
+
+// rankCandidates scores dictionary entries as suggestions for word and
+// returns the best n, or all if n < 1. cands maps the entries to the
+// weights of the key levels they matched at.
+func rankCandidates(word string, cands map[*entry]float64, layout *KeyboardLayout, n int) []Suggestion {
+	maxFreq := 1
+	for e := range cands {
+		if e.freq > maxFreq {
+			maxFreq = e.freq
+		}
+	}
+
+	out := make([]Suggestion, 0, len(cands))
+	for e, w := range cands {
+		if e.word == word {
+			out = append(out, Suggestion{Word: word, Score: 1})
+			continue
+		}
+
+		var (
+			sim  = graphemeSimilarity(word, e.word)
+			freq = math.Log1p(float64(e.freq)) / math.Log1p(float64(maxFreq))
+		)
+		if layout != nil {
+			sim = math.Max(sim, layout.TypoSimilarity(word, e.word))
+		}
+		out = append(out, Suggestion{
+			Word:  e.word,
+			Score: w * (0.7*sim + 0.3*freq),
+		})
+	}
+
+	sortSuggestions(out)
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// Complete returns up to n dictionary words that are likely completions
+// of a partially typed Latin (Thanglish) word, best first. The input is
+// encoded with EncodeRoman and matched against the key0 prefixes of the
+// dictionary words. Candidates are ranked by how much of their key is
+// already typed and by their frequency.
+func (d *Dictionary) Complete(prefix string, n int) []Suggestion {
+	key := d.enc.EncodeRoman(prefix)
+	if key == "" {
+		return nil
+	}
+
+	d.mu.Lock()
+	if d.dirty {
+		d.sorted = d.sorted[:0]
+		for k := range d.index[Key0] {
+			d.sorted = append(d.sorted, k)
+		}
+		sort.Strings(d.sorted)
+		d.dirty = false
+	}
+
+	var (
+		cands   []*entry
+		maxFreq = 1
+	)
+	for i := sort.SearchStrings(d.sorted, key); i < len(d.sorted); i++ {
+		if !strings.HasPrefix(d.sorted[i], key) {
+			break
+		}
+		for _, e := range d.index[Key0][d.sorted[i]] {
+			cands = append(cands, e)
+			if e.freq > maxFreq {
+				maxFreq = e.freq
+			}
+		}
+	}
+	d.mu.Unlock()
+
+	out := make([]Suggestion, 0, len(cands))
+	for _, e := range cands {
+		var (
+			typed = float64(len(key)) / float64(len(e.keys[Key0]))
+			freq  = math.Log1p(float64(e.freq)) / math.Log1p(float64(maxFreq))
+		)
+		out = append(out, Suggestion{Word: e.word, Score: 0.5*typed + 0.5*freq})
+	}
+	sortSuggestions(out)
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// DidYouMean returns the single best alternative spelling for a query
+// that isn't in the dictionary, with a confidence between 0 and 1. It
+// returns an empty suggestion if the query is an exact match or if no
+// phonetically similar word is known.
+func (d *Dictionary) DidYouMean(query string) (string, float64) {
+	if d.Contains(query) {
+		return "", 0
+	}
+
+	s := d.Suggest(query, 1)
+	if len(s) == 0 {
+		return "", 0
+	}
+	return s[0].Word, s[0].Score
+}
+
+// sortSuggestions sorts suggestions by descending score, breaking ties
+// by word.
+func sortSuggestions(s []Suggestion) {
+	sort.Slice(s, func(i, j int) bool {
+		if s[i].Score != s[j].Score {
+			return s[i].Score > s[j].Score
+		}
+		return s[i].Word < s[j].Word
+	})
+}
+
+// GraphemeDistance returns the Levenshtein distance between two strings
+// counted in grapheme clusters (Graphemes) rather than runes or bytes, so
+// that a changed vowel sign (கொடு, கோடு) or a dropped virama is one edit.
+func GraphemeDistance(a, b string) int {
+	ga, gb := Graphemes(a), Graphemes(b)
+	return editDistance(len(ga), len(gb), func(i, j int) bool { return ga[i] == gb[j] })
+}
+
+// graphemeSimilarity returns the GraphemeDistance of two strings
+// normalised to a similarity between 0 and 1.
+func graphemeSimilarity(a, b string) float64 {
+	return normSimilarity(len(Graphemes(a)), len(Graphemes(b)), GraphemeDistance(a, b))
+}
+
+// similarity returns the Levenshtein similarity of two rune slices
+// normalised to a value between 0 and 1.
+func similarity(a, b []rune) float64 {
+	return normSimilarity(len(a), len(b), levenshtein(a, b))
+}
+
+// normSimilarity normalises the edit distance d between sequences of
+// lengths n and m to a similarity between 0 and 1.
+func normSimilarity(n, m, d int) float64 {
+	max := n
+	if m > max {
+		max = m
+	}
+	if max == 0 {
+		return 1
+	}
+	return 1 - float64(d)/float64(max)
+}
+
+// levenshtein returns the edit distance between two slices.
+func levenshtein(a, b []rune) int {
+	return editDistance(len(a), len(b), func(i, j int) bool { return a[i] == b[j] })
+}
+
+// editDistance returns the Levenshtein distance between sequences of
+// lengths n and m whose elements are compared with eq.
+func editDistance(n, m int, eq func(i, j int) bool) int {
+	prev := make([]int, m+1)
+	cur := make([]int, m+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= n; i++ {
+		cur[0] = i
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if eq(i-1, j-1) {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[m]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
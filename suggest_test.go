@@ -0,0 +1,135 @@
+package taphone
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testDictionary returns a small dictionary of words with near homophones.
+func testDictionary() *Dictionary {
+	d := NewDictionary(New())
+	for w, f := range map[string]int{
+		"கடல்": 50, "கடள்": 1, "கடன்": 30, "மழை": 20, "மலை": 40,
+		"வணக்கம்": 10, "பள்ளி": 5, "பல்லி": 2,
+	} {
+		d.Add(w, f)
+	}
+	return d
+}
+
+func suggestionWords(s []Suggestion) []string {
+	var out []string
+	for _, x := range s {
+		out = append(out, x.Word)
+	}
+	return out
+}
+
+func TestSuggest(t *testing.T) {
+	d := testDictionary()
+	cases := []struct {
+		name string
+		word string
+		n    int
+		want []string
+	}{
+		{"known word first", "கடல்", 0, []string{"கடல்", "கடள்"}},
+		{"misspelt l", "மளை", 0, []string{"மலை"}},
+		{"misspelt n", "வனக்கம்", 0, []string{"வணக்கம்"}},
+		{"closer spelling first", "பள்ளீ", 0, []string{"பள்ளி", "பல்லி"}},
+		{"limit", "பள்ளீ", 1, []string{"பள்ளி"}},
+		{"no match", "சூரியன்", 0, nil},
+		{"no key", "hello", 0, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := d.Suggest(c.word, c.n)
+			if got := suggestionWords(s); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Suggest(%q, %d) = %q, want %q", c.word, c.n, got, c.want)
+			}
+			for i, x := range s {
+				if x.Score <= 0 || x.Score > 1 || (i > 0 && x.Score > s[i-1].Score) {
+					t.Errorf("Suggest(%q) scores out of order or range: %v", c.word, s)
+				}
+			}
+		})
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	d := testDictionary()
+	cases := []struct {
+		query string
+		want  string
+	}{
+		{"மளை", "மலை"},
+		{"வனக்கம்", "வணக்கம்"},
+		{"கடல்", ""},
+		{"சூரியன்", ""},
+		{"", ""},
+	}
+	for _, c := range cases {
+		got, score := d.DidYouMean(c.query)
+		if got != c.want || (got == "") != (score == 0) {
+			t.Errorf("DidYouMean(%q) = %q, %v, want %q", c.query, got, score, c.want)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	d := testDictionary()
+	cases := []struct {
+		name   string
+		prefix string
+		n      int
+		want   []string
+	}{
+		{"frequent first", "kad", 0, []string{"கடல்", "கடன்", "கடள்"}},
+		{"limit", "kad", 1, []string{"கடல்"}},
+		{"longer key typed", "ma", 0, []string{"மலை", "மழை"}},
+		{"variant spelling", "vanakk", 0, []string{"வணக்கம்"}},
+		{"complete word", "vanakkam", 0, []string{"வணக்கம்"}},
+		{"no completion", "thamizh", 0, nil},
+		{"empty", "", 0, nil},
+		{"no letters", "123", 0, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := d.Complete(c.prefix, c.n)
+			if got := suggestionWords(s); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Complete(%q, %d) = %q, want %q", c.prefix, c.n, got, c.want)
+			}
+		})
+	}
+
+	// Words added after a completion are found by the next one.
+	d.Add("கடவுள்", 100)
+	if got := suggestionWords(d.Complete("kadav", 0)); !reflect.DeepEqual(got, []string{"கடவுள்"}) {
+		t.Errorf("Complete(kadav) after Add = %q", got)
+	}
+}
+
+func TestGraphemeDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"கொடு", "கோடு", 1},
+		{"கடல்", "கடல", 1},
+		{"கடல்", "கடள்", 1},
+		{"பள்ளி", "பல்லி", 2},
+		{"வணக்கம்", "வணக்கம்", 0},
+		{"வணக்கம்", "", 5},
+		{"மரம்", "மரங்கள்", 3},
+		{"abc", "abd", 1},
+		{"", "", 0},
+	}
+	for _, c := range cases {
+		if got := GraphemeDistance(c.a, c.b); got != c.want {
+			t.Errorf("GraphemeDistance(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+		if got := GraphemeDistance(c.b, c.a); got != c.want {
+			t.Errorf("GraphemeDistance(%q, %q) = %d, want %d", c.b, c.a, got, c.want)
+		}
+	}
+}
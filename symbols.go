@@ -0,0 +1,68 @@
+package taphone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// StripSymbols removes emoji, dingbats and other pictographic symbols,
+// with their modifiers (skin tones, variation selectors, keycaps, tags)
+// and the joiners that combine them. A run of symbols between two words
+// (நன்றி🙏வணக்கம்) is replaced by a space; elsewhere it is removed
+// (வணக்கம்🙏 → வணக்கம்). Currency and mathematical symbols, and the Tamil
+// signs for day, month, year, rupee and the like (௳–௺), are kept. To
+// remove symbols inserted inside words, use Deobfuscate.
+func StripSymbols(s string) string {
+	var (
+		rs  = []rune(s)
+		out strings.Builder
+	)
+	for i := 0; i < len(rs); i++ {
+		if isSymbolModifier(rs[i]) {
+			continue
+		}
+		if !isSymbol(rs[i]) {
+			out.WriteRune(rs[i])
+			continue
+		}
+
+		// Skip the run of symbols, their modifiers and joiners.
+		j := i
+		for j < len(rs) && (isSymbol(rs[j]) || isSymbolModifier(rs[j]) || rs[j] == zwj) {
+			j++
+		}
+		if i > 0 && j < len(rs) && isWordRune(rs[i-1]) && isWordRune(rs[j]) {
+			out.WriteByte(' ')
+		}
+		i = j - 1
+	}
+	return out.String()
+}
+
+// WithSymbolStripping removes emoji and other symbols from the input
+// with StripSymbols before it is encoded.
+func WithSymbolStripping() Option {
+	return func(k *TAphone) {
+		k.addFilter("symbols", StripSymbols)
+	}
+}
+
+// isSymbol reports whether r is an emoji or other pictographic symbol.
+// The Tamil signs ௳–௺ are Tamil text and not symbols.
+func isSymbol(r rune) bool {
+	if r >= 0x0bf3 && r <= 0x0bfa {
+		return false
+	}
+	return unicode.Is(unicode.So, r) || (r >= 0x1f3fb && r <= 0x1f3ff)
+}
+
+// isSymbolModifier reports whether r modifies a preceding symbol:
+// variation selectors, the combining keycap and emoji tag characters.
+func isSymbolModifier(r rune) bool {
+	return (r >= 0xfe00 && r <= 0xfe0f) || r == 0x20e3 || (r >= 0xe0020 && r <= 0xe007f)
+}
+
+// isWordRune reports whether r is a letter, mark or digit.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.Is(unicode.M, r) || unicode.IsDigit(r)
+}
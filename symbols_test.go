@@ -0,0 +1,36 @@
+package taphone
+
+import "testing"
+
+func TestStripSymbols(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"trailing emoji", "வணக்கம்🙏", "வணக்கம்"},
+		{"between words", "நன்றி🙏வணக்கம்", "நன்றி வணக்கம்"},
+		{"next to a space", "நன்றி 🙏 வணக்கம்", "நன்றி  வணக்கம்"},
+		{"skin tone", "நன்றி🙏🏽வணக்கம்", "நன்றி வணக்கம்"},
+		{"zwj sequence", "அம்மா👩‍👧", "அம்மா"},
+		{"variation selector", "மரம்❤️", "மரம்"},
+		{"keycap", "1️⃣ மரம்", "1 மரம்"},
+		{"currency and maths", "₹100 + 5", "₹100 + 5"},
+		{"tamil signs", "௳ ௹100", "௳ ௹100"},
+		{"no symbols", "வணக்கம்", "வணக்கம்"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := StripSymbols(c.input); got != c.want {
+				t.Errorf("StripSymbols(%q) = %q, want %q", c.input, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWithSymbolStripping(t *testing.T) {
+	k := New(WithSymbolStripping())
+	if got, _, _ := k.EncodePhrase("நன்றி🙏வணக்கம்"); got != "NNR3 VNKKM" {
+		t.Errorf("EncodePhrase() = %q, want %q", got, "NNR3 VNKKM")
+	}
+}
@@ -27,6 +27,7 @@ package taphone
 import (
 	"regexp"
 	"strings"
+	"unicode"
 )
 
 var vowels = map[string]string{
@@ -64,19 +65,72 @@ var (
 	regexAlphaNum, _ = regexp.Compile(`[^0-9A-Z]`)
 )
 
+// KeyLevel selects one of the three phonetic keys generated by Encode.
+type KeyLevel int
+
+// Key levels in increasing order of phonetic affinity.
+const (
+	Key0 KeyLevel = iota
+	Key1
+	Key2
+)
+
 // TAphone is the Tamil-phone tokenizer.
 type TAphone struct {
 	modCompounds  *regexp.Regexp
 	modConsonants *regexp.Regexp
 	modVowels     *regexp.Regexp
+
+	// filters preprocess input before it is encoded. filterNames holds
+	// their names for Rules.
+	filters     []CharFilter
+	filterNames []string
+
+	// profiles and expansions are the profiles and abbreviation
+	// expansions configured, for Rules.
+	profiles   []Profile
+	expansions map[string]string
+
+	// stopwords are dropped from phrases by EncodePhrase.
+	stopwords map[string]bool
+
+	// phraseSep separates the word keys in EncodePhrase keys.
+	phraseSep string
+
+	// disambiguate appends disambiguators to short key1 and key2 keys.
+	disambiguate bool
+
+	// lengthBuckets prefixes key0 with the word's length bucket.
+	lengthBuckets bool
+
+	// ngram is the n-gram size of fallback keys, 0 if disabled.
+	ngram int
+
+	// lowercase and verbose set the style of the keys.
+	lowercase bool
+	verbose   bool
+
+	// maxLen is the maximum number of codes in a key, 0 if unlimited.
+	maxLen int
+
+	// loanSounds codes Grantha letters and loan sounds, and loanFold
+	// folds the foreign ones in key0 and key1.
+	loanSounds bool
+	loanFold   bool
+
+	// progress receives the progress of bulk operations, if set.
+	progress ProgressFunc
 }
 
 // New returns a new instance of the KNPhone tokenizer.
-func New() *TAphone {
+func New(opts ...Option) *TAphone {
 	var (
 		glyphs []string
 		mods   []string
-		kn     = &TAphone{}
+		kn     = &TAphone{
+			stopwords: defaultStopwords,
+			phraseSep: SeparatorSpace,
+		}
 	)
 
 	// modifiers.
@@ -104,16 +158,62 @@ func New() *TAphone {
 	}
 	kn.modVowels, _ = regexp.Compile(`((` + strings.Join(glyphs, "|") + `)(` + strings.Join(mods, "|") + `))`)
 
+	for _, o := range opts {
+		o(kn)
+	}
 	return kn
 }
 
 // Encode encodes a unicode Tamil string to its Roman TAPhone hash.
 // Ideally, words should be encoded one at a time, and not as phrases
 // or sentences.
-func (k *TAphone) Encode(input string) (string, string, string) {
+//
+// Options passed to Encode apply to this call only, on top of the
+// encoder's own options, so that one encoder can serve callers with
+// different settings.
+func (k *TAphone) Encode(input string, opts ...EncodeOption) (string, string, string) {
+	if len(opts) > 0 {
+		k = k.with(opts)
+	}
+	keys := k.keys(input)
+	return keys[Key0], keys[Key1], keys[Key2]
+}
+
+// EncodeLevel encodes a unicode Tamil string and returns its key at the
+// given level.
+func (k *TAphone) EncodeLevel(input string, level KeyLevel) string {
+	return k.keys(input)[level]
+}
+
+// keys preprocesses input and returns its three keys indexed by KeyLevel.
+func (k *TAphone) keys(input string) [3]string {
+	return k.encode(k.preprocess(input))
+}
+
+// addFilter adds a named filter to preprocess input with.
+func (k *TAphone) addFilter(name string, f CharFilter) {
+	k.filters = append(k.filters, f)
+	k.filterNames = append(k.filterNames, name)
+}
+
+// preprocess runs the configured filters on input.
+func (k *TAphone) preprocess(input string) string {
+	for _, f := range k.filters {
+		input = f(input)
+	}
+	return input
+}
+
+// encode returns the three keys of a preprocessed input.
+func (k *TAphone) encode(input string) [3]string {
 	// key2 accounts for hard and modified sounds.
-	key2 := k.process(input)
+	return k.derive(input, k.process(input), true)
+}
 
+// derive returns the three keys of a preprocessed input from its key2
+// codes. The input may also be Latin text keyed by EncodeRoman, which
+// passes fallback false as its codes already stand for the Latin letters.
+func (k *TAphone) derive(input, key2 string, fallback bool) [3]string {
 	// key1 loses numeric modifiers that denote phonetic modifiers.
 	key1 := regexKey1.ReplaceAllString(key2, "")
 
@@ -121,13 +221,50 @@ func (k *TAphone) Encode(input string) (string, string, string) {
 	// and phonetic modifiers.
 	key0 := regexKey0.ReplaceAllString(key2, "")
 
-	return key0, key1, key2
+	if k.loanFold {
+		key0, key1 = foldLoanCodes(key0), foldLoanCodes(key1)
+	}
+	if k.maxLen > 0 {
+		key0, key1, key2 = truncateKey(key0, k.maxLen), truncateKey(key1, k.maxLen), truncateKey(key2, k.maxLen)
+	}
+
+	// Optional decorations are derived from the plain codes of key0.
+	codes := key0
+	if k.verbose {
+		key0, key1, key2 = verboseKey(key0), verboseKey(key1), verboseKey(key2)
+	}
+	if k.disambiguate && codes != "" {
+		if d := disambiguator(input, codes); d != "" {
+			key1 += d
+			key2 += d
+		}
+	}
+	if k.lengthBuckets && codes != "" {
+		key0 = lengthBucket(input, codes) + key0
+	}
+	if k.ngram > 0 && fallback {
+		if f := k.ngramFallback(input, codes); f != "" {
+			key0, key1, key2 = key0+f, key1+f, key2+f
+		}
+	}
+	if k.lowercase {
+		key0, key1, key2 = strings.ToLower(key0), strings.ToLower(key1), strings.ToLower(key2)
+	}
+	return [3]string{key0, key1, key2}
 }
 
 func (k *TAphone) process(input string) string {
 	// Remove all non-malayalam characters.
 	input = regexNonTamil.ReplaceAllString(strings.Trim(input, ""), "")
 
+	// Replace and group loan sounds, which include consonants that are
+	// replaced below (ஃப).
+	if k.loanSounds {
+		for _, l := range loanLetters {
+			input = strings.ReplaceAll(input, l[0], `{`+l[1]+`}`)
+		}
+	}
+
 	// All character replacements are grouped between { and } to maintain
 	// separatability till the final step.
 
@@ -162,6 +299,63 @@ func (k *TAphone) process(input string) string {
 	return regexAlphaNum.ReplaceAllString(input, "")
 }
 
+// runeCodes maps each letter and vowel sign that the tables code to its
+// code, for processRunes.
+var runeCodes = func() map[rune]string {
+	out := make(map[rune]string)
+	for _, m := range []map[string]string{consonants, vowels, modifiers} {
+		for g, c := range m {
+			out[[]rune(g)[0]] = c
+		}
+	}
+	return out
+}()
+
+// loanRunes maps the loan letters and the letters that follow ஃ in loan
+// sounds to their codes, for processRunes.
+var loanRunes, aythamLoanRunes = func() (map[rune]string, map[rune]string) {
+	single, aytham := make(map[rune]string), make(map[rune]string)
+	for _, l := range loanLetters {
+		switch r := []rune(l[0]); {
+		case len(r) == 1:
+			single[r[0]] = l[1]
+		case r[0] == 'ஃ':
+			aytham[r[1]] = l[1]
+		}
+	}
+	return single, aytham
+}()
+
+// processRunes returns the same codes as process for input held as
+// runes. As the codes of the glyphs don't depend on their neighbours,
+// each Tamil rune is coded on its own, but for the loan sounds written
+// with ஃ.
+func (k *TAphone) processRunes(input []rune) string {
+	var (
+		out    strings.Builder
+		aytham bool
+	)
+	for _, r := range input {
+		if !unicode.Is(unicode.Tamil, r) {
+			continue
+		}
+		if k.loanSounds {
+			if c, ok := aythamLoanRunes[r]; ok && aytham {
+				out.WriteString(c)
+				aytham = false
+				continue
+			}
+			aytham = r == 'ஃ'
+			if c, ok := loanRunes[r]; ok {
+				out.WriteString(c)
+				continue
+			}
+		}
+		out.WriteString(runeCodes[r])
+	}
+	return out.String()
+}
+
 func (k *TAphone) replaceModifiedGlyphs(input string, glyphs map[string]string, r *regexp.Regexp) string {
 	for _, matches := range r.FindAllStringSubmatch(input, -1) {
 		for _, m := range matches {
@@ -0,0 +1,37 @@
+package taphone
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGolden checks the keys of the cases in testdata/golden.tsv, which
+// are edge cases collected from real text with
+//
+//	taphone seeds -golden testdata/golden.tsv -fuzz testdata/fuzz/FuzzEncode corpus.txt
+//
+// Regenerate the file when the keys change deliberately.
+func TestGolden(t *testing.T) {
+	f, err := os.Open("testdata/golden.tsv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	k := New()
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		c := strings.Split(sc.Text(), "\t")
+		if len(c) != 4 {
+			t.Fatalf("golden.tsv:%d: %d fields, want 4", n, len(c))
+		}
+		if k0, k1, k2 := k.Encode(c[0]); k0 != c[1] || k1 != c[2] || k2 != c[3] {
+			t.Errorf("Encode(%q) = %s %s %s, want %s %s %s", c[0], k0, k1, k2, c[1], c[2], c[3])
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
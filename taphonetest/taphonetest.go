@@ -0,0 +1,141 @@
+// Package taphonetest provides test helpers for applications that match
+// Tamil text with taphone, so that their matching behaviour can be
+// tested with readable assertions and tables of cases.
+//
+//	func TestNames(t *testing.T) {
+//		taphonetest.AssertSoundsLike(t, "கொடு", "கோடு", taphone.Key0)
+//
+//		taphonetest.Run(t, taphone.New(taphone.WithProfile(taphone.NameProfile)), []taphonetest.Case{
+//			{A: "லட்சுமி", B: "லக்ஷ்மி", Level: taphone.Key2},
+//			{A: "கண்ணன்", B: "கன்னன்", Level: taphone.Key2, Distinct: true},
+//		})
+//	}
+package taphonetest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+// Matcher makes assertions with an encoder.
+type Matcher struct {
+	Enc *taphone.TAphone
+}
+
+// Case is a table driven test case: A and B sound alike at Level, or are
+// distinct at Level if Distinct is set.
+type Case struct {
+	// Name names the subtest. It defaults to "A~B", or "A!~B" for
+	// distinct cases.
+	Name string
+
+	A, B     string
+	Level    taphone.KeyLevel
+	Distinct bool
+}
+
+var defaultMatcher = &Matcher{Enc: taphone.New()}
+
+// New returns a Matcher that uses enc.
+func New(enc *taphone.TAphone) *Matcher {
+	return &Matcher{Enc: enc}
+}
+
+// AssertSoundsLike fails the test if a and b don't share their key at
+// level under the default encoder.
+func AssertSoundsLike(t testing.TB, a, b string, level taphone.KeyLevel) {
+	t.Helper()
+	defaultMatcher.AssertSoundsLike(t, a, b, level)
+}
+
+// AssertDistinct fails the test if a and b share their key at level under
+// the default encoder.
+func AssertDistinct(t testing.TB, a, b string, level taphone.KeyLevel) {
+	t.Helper()
+	defaultMatcher.AssertDistinct(t, a, b, level)
+}
+
+// AssertKeys fails the test if the keys of word under the default encoder
+// aren't key0, key1 and key2.
+func AssertKeys(t testing.TB, word, key0, key1, key2 string) {
+	t.Helper()
+	defaultMatcher.AssertKeys(t, word, key0, key1, key2)
+}
+
+// Run runs each case as a subtest of t with enc, or the default encoder
+// if enc is nil.
+func Run(t *testing.T, enc *taphone.TAphone, cases []Case) {
+	t.Helper()
+	m := defaultMatcher
+	if enc != nil {
+		m = New(enc)
+	}
+	m.Run(t, cases)
+}
+
+// SoundsLike reports whether a and b share their key at level.
+func (m *Matcher) SoundsLike(a, b string, level taphone.KeyLevel) bool {
+	ka := m.Enc.EncodeLevel(a, level)
+	return ka != "" && ka == m.Enc.EncodeLevel(b, level)
+}
+
+// AssertSoundsLike fails the test if a and b don't share their key at
+// level.
+func (m *Matcher) AssertSoundsLike(t testing.TB, a, b string, level taphone.KeyLevel) {
+	t.Helper()
+	if !m.SoundsLike(a, b, level) {
+		t.Errorf("%s and %s don't sound alike at key%d:\n%s", a, b, level, m.describe(a, b))
+	}
+}
+
+// AssertDistinct fails the test if a and b share their key at level.
+func (m *Matcher) AssertDistinct(t testing.TB, a, b string, level taphone.KeyLevel) {
+	t.Helper()
+	if m.SoundsLike(a, b, level) {
+		t.Errorf("%s and %s sound alike at key%d:\n%s", a, b, level, m.describe(a, b))
+	}
+}
+
+// AssertKeys fails the test if the keys of word aren't key0, key1 and
+// key2.
+func (m *Matcher) AssertKeys(t testing.TB, word, key0, key1, key2 string) {
+	t.Helper()
+	k0, k1, k2 := m.Enc.Encode(word)
+	if k0 != key0 || k1 != key1 || k2 != key2 {
+		t.Errorf("keys of %s = %q %q %q, want %q %q %q", word, k0, k1, k2, key0, key1, key2)
+	}
+}
+
+// Run runs each case as a subtest of t.
+func (m *Matcher) Run(t *testing.T, cases []Case) {
+	t.Helper()
+	for _, c := range cases {
+		c := c
+		name := c.Name
+		if name == "" {
+			op := "~"
+			if c.Distinct {
+				op = "!~"
+			}
+			name = c.A + op + c.B
+		}
+
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+			if c.Distinct {
+				m.AssertDistinct(t, c.A, c.B, c.Level)
+			} else {
+				m.AssertSoundsLike(t, c.A, c.B, c.Level)
+			}
+		})
+	}
+}
+
+// describe returns the keys of a and b for failure messages.
+func (m *Matcher) describe(a, b string) string {
+	ka0, ka1, ka2 := m.Enc.Encode(a)
+	kb0, kb1, kb2 := m.Enc.Encode(b)
+	return fmt.Sprintf("\t%s: %q %q %q\n\t%s: %q %q %q", a, ka0, ka1, ka2, b, kb0, kb1, kb2)
+}
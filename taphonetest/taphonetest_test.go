@@ -0,0 +1,70 @@
+package taphonetest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cmrajan/taphone"
+)
+
+// recorder records the failures of assertions.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	names := New(taphone.New(taphone.WithProfile(taphone.NameProfile)))
+	cases := []struct {
+		name   string
+		assert func(testing.TB)
+		fail   string
+	}{
+		{"sounds like", func(t testing.TB) { AssertSoundsLike(t, "கொடு", "கோடு", taphone.Key0) }, ""},
+		{"doesn't sound like", func(t testing.TB) { AssertSoundsLike(t, "கொடு", "கடு", taphone.Key2) },
+			"கொடு and கடு don't sound alike at key2"},
+		{"no keys don't sound alike", func(t testing.TB) { AssertSoundsLike(t, "hello", "hello", taphone.Key0) },
+			"don't sound alike"},
+		{"distinct", func(t testing.TB) { AssertDistinct(t, "கண்ணன்", "கன்னன்", taphone.Key2) }, ""},
+		{"not distinct", func(t testing.TB) { AssertDistinct(t, "கண்ணன்", "கன்னன்", taphone.Key0) },
+			"கண்ணன் and கன்னன் sound alike at key0"},
+		{"keys", func(t testing.TB) { AssertKeys(t, "முருகன்", "MRKN", "MRKN1", "M4R4KN1") }, ""},
+		{"wrong keys", func(t testing.TB) { AssertKeys(t, "முருகன்", "MRKN", "MRKN", "MRKN") },
+			`keys of முருகன் = "MRKN" "MRKN1" "M4R4KN1"`},
+		{"matcher encoder", func(t testing.TB) {
+			names.AssertSoundsLike(t, "லட்சுமி", "லக்ஷ்மி", taphone.Key2)
+		}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			c.assert(r)
+			switch {
+			case c.fail == "" && len(r.errors) > 0:
+				t.Errorf("assertion failed: %s", r.errors)
+			case c.fail != "" && len(r.errors) == 0:
+				t.Errorf("assertion passed, want it to fail with %q", c.fail)
+			case c.fail != "" && !strings.Contains(r.errors[0], c.fail):
+				t.Errorf("assertion failed with %q, want %q", r.errors[0], c.fail)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	Run(t, nil, []Case{
+		{A: "கொடு", B: "கோடு", Level: taphone.Key0},
+		{A: "கொடு", B: "கடு", Level: taphone.Key2, Distinct: true},
+	})
+	Run(t, taphone.New(taphone.WithProfile(taphone.NameProfile)), []Case{
+		{Name: "ksha", A: "லட்சுமி", B: "லக்ஷ்மி", Level: taphone.Key2},
+		{A: "கண்ணன்", B: "கன்னன்", Level: taphone.Key2, Distinct: true},
+	})
+}
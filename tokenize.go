@@ -0,0 +1,171 @@
+package taphone
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind is the kind of a token produced by Tokenize.
+type TokenKind int
+
+// Token kinds.
+const (
+	TokenTamil TokenKind = iota
+	TokenLatin
+	TokenNumber
+	TokenPunct
+	TokenOther
+)
+
+const (
+	zwnj = '\u200c'
+	zwj  = '\u200d'
+)
+
+// Token is a token in a text with its byte offsets.
+type Token struct {
+	// Text is the token as it appears in the text, text[Start:End].
+	Text  string
+	Kind  TokenKind
+	Start int
+	End   int
+
+	// Base is the token without its clitic, restored to its standalone
+	// spelling (அவனும் → அவன்). It is the form that should be encoded.
+	// It equals Text if the token has no clitic.
+	Base string
+
+	// Clitic is the clitic split off the token, if any, in its
+	// standalone spelling (உம், ஆ).
+	Clitic string
+}
+
+// cliticHosts are consonants after which a word final உம் is a clitic
+// rather than part of a verb ending (வரும், இருக்கும்).
+var cliticHosts = map[rune]bool{
+	'ன': true, 'ள': true, 'ல': true, 'ழ': true, 'ண': true, 'ம': true,
+}
+
+// cliticVerbRoots are verb roots ending in a clitic host whose habitual
+// and future forms end in உம் like a noun with the clitic (கொள்ளும்,
+// செல்லும், வாழும், காணும்), so that they are not split.
+var cliticVerbRoots = makeSet([]string{
+	"கொள்", "செல்", "சொல்", "கொல்", "வெல்", "மெல்", "நில்", "உண்", "தின்",
+	"பண்", "எண்", "மின்", "பின்", "துள்", "தள்", "அள்", "கிள்", "வாழ்",
+	"ஆள்", "காண்", "பேண்", "வீழ்", "சூழ்", "மாள்", "நாண்", "விழ்", "அழ்",
+	"உழ்", "தொழ்", "கேள்", "ஆழ்", "மூழ்", "தாழ்",
+})
+
+// Tokenize splits text into tokens ready for per-word encoding. Runs of
+// Tamil letters, Latin letters, letters of other scripts and numerals
+// (Arabic and Tamil digits, with embedded separators) form tokens, and
+// each punctuation mark or symbol is a token of its own. Mixed script
+// words are split at script boundaries. The clitic உம் and the question
+// particle ஆ (when followed by ?) are split off Tamil words into the
+// Clitic field. Whitespace is dropped.
+func Tokenize(text string) []Token {
+	var (
+		out   []Token
+		start = -1
+		kind  TokenKind
+	)
+	flush := func(end int) {
+		if start > -1 {
+			t := text[start:end]
+			out = append(out, Token{Text: t, Kind: kind, Start: start, End: end, Base: t})
+			start = -1
+		}
+	}
+
+	for i, r := range text {
+		// Combining marks and joiners extend the current token.
+		if start > -1 && (unicode.Is(unicode.M, r) || r == zwnj || r == zwj) {
+			continue
+		}
+
+		k, ok := runeKind(r)
+		if !ok {
+			flush(i)
+			continue
+		}
+
+		// Separators inside numbers (1,000.50).
+		if start > -1 && kind == TokenNumber && (r == ',' || r == '.') {
+			if next, _ := utf8.DecodeRuneInString(text[i+1:]); isDigit(next) {
+				continue
+			}
+		}
+
+		if start > -1 && (k != kind || k == TokenPunct) {
+			flush(i)
+		}
+		if start == -1 {
+			start, kind = i, k
+		}
+	}
+	flush(len(text))
+
+	for i := range out {
+		if out[i].Kind == TokenTamil {
+			splitClitic(&out[i], out[i+1:])
+		}
+	}
+	return out
+}
+
+// runeKind returns the kind of token r starts, and false for whitespace
+// and control characters.
+func runeKind(r rune) (TokenKind, bool) {
+	switch {
+	case unicode.IsSpace(r) || unicode.IsControl(r):
+		return 0, false
+	case isDigit(r):
+		return TokenNumber, true
+	case unicode.Is(unicode.Tamil, r) && (unicode.IsLetter(r) || unicode.Is(unicode.M, r)):
+		return TokenTamil, true
+	case unicode.Is(unicode.Latin, r):
+		return TokenLatin, true
+	case unicode.IsLetter(r):
+		return TokenOther, true
+	}
+	return TokenPunct, true
+}
+
+// isDigit reports whether r is an Arabic or Tamil digit.
+func isDigit(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= '௦' && r <= '௯')
+}
+
+// splitClitic splits a clitic off a Tamil word token. next holds the
+// tokens that follow it.
+func splitClitic(t *Token, next []Token) {
+	r := []rune(t.Text)
+
+	// உம் after a noun ending in a sonorant: அவனும் → அவன் + உம், with
+	// the sonorant doubled after a short syllable: கல்லும் → கல் + உம்,
+	// or after a long vowel with a v glide: அம்மாவும் → அம்மா + உம்.
+	// Verb forms with the same ending (கொள்ளும்) are left whole.
+	if n := len(r); n > 3 && strings.HasSuffix(t.Text, "ும்") {
+		host := r[n-4]
+		switch {
+		case cliticHosts[host]:
+			base := string(r[:n-3]) + "்"
+			if n > 5 && r[n-5] == '்' && r[n-6] == host {
+				base = string(r[:n-5]) + "்"
+			}
+			if !cliticVerbRoots[base] {
+				t.Base, t.Clitic = base, "உம்"
+			}
+		case host == 'வ' && n > 4 && (r[n-5] == 'ா' || r[n-5] == 'ூ' || r[n-5] == 'ோ'):
+			t.Base, t.Clitic = string(r[:n-4]), "உம்"
+		}
+		return
+	}
+
+	// Question particle ஆ before a question mark: வந்தானா? → வந்தான் + ஆ.
+	if n := len(r); n > 2 && r[n-1] == 'ா' && len(next) > 0 && next[0].Text == "?" &&
+		next[0].Start == t.End && !unicode.Is(unicode.M, r[n-2]) {
+		t.Base, t.Clitic = string(r[:n-1])+"்", "ஆ"
+	}
+}
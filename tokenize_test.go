@@ -0,0 +1,61 @@
+package taphone
+
+import "testing"
+
+func TestTokenize(t *testing.T) {
+	type tok struct {
+		text, base, clitic string
+		kind               TokenKind
+	}
+	cases := []struct {
+		name  string
+		input string
+		want  []tok
+	}{
+		{"words and punctuation", "வணக்கம், hello!", []tok{
+			{"வணக்கம்", "வணக்கம்", "", TokenTamil},
+			{",", ",", "", TokenPunct},
+			{"hello", "hello", "", TokenLatin},
+			{"!", "!", "", TokenPunct},
+		}},
+		{"numbers keep separators", "1,000.50 ௧௨", []tok{
+			{"1,000.50", "1,000.50", "", TokenNumber},
+			{"௧௨", "௧௨", "", TokenNumber},
+		}},
+		{"mixed script", "Tamilதமிழ்", []tok{
+			{"Tamil", "Tamil", "", TokenLatin},
+			{"தமிழ்", "தமிழ்", "", TokenTamil},
+		}},
+		{"clitic after a sonorant", "அவனும்", []tok{{"அவனும்", "அவன்", "உம்", TokenTamil}}},
+		{"clitic after a geminate", "கல்லும்", []tok{{"கல்லும்", "கல்", "உம்", TokenTamil}}},
+		{"clitic after ள", "வாளும்", []tok{{"வாளும்", "வாள்", "உம்", TokenTamil}}},
+		{"clitic after a v glide", "அம்மாவும்", []tok{{"அம்மாவும்", "அம்மா", "உம்", TokenTamil}}},
+		{"habitual verb கொள்", "கொள்ளும்", []tok{{"கொள்ளும்", "கொள்ளும்", "", TokenTamil}}},
+		{"habitual verb செல்", "செல்லும்", []tok{{"செல்லும்", "செல்லும்", "", TokenTamil}}},
+		{"habitual verb வாழ்", "வாழும்", []tok{{"வாழும்", "வாழும்", "", TokenTamil}}},
+		{"habitual verb காண்", "காணும்", []tok{{"காணும்", "காணும்", "", TokenTamil}}},
+		{"verb ending after a stop", "இருக்கும்", []tok{{"இருக்கும்", "இருக்கும்", "", TokenTamil}}},
+		{"question particle", "வந்தானா?", []tok{
+			{"வந்தானா", "வந்தான்", "ஆ", TokenTamil},
+			{"?", "?", "", TokenPunct},
+		}},
+		{"no particle without a question mark", "வந்தானா", []tok{{"வந்தானா", "வந்தானா", "", TokenTamil}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := Tokenize(c.input)
+			if len(got) != len(c.want) {
+				t.Fatalf("Tokenize(%q) = %d tokens %+v, want %d", c.input, len(got), got, len(c.want))
+			}
+			for i, w := range c.want {
+				g := got[i]
+				if g.Text != w.text || g.Base != w.base || g.Clitic != w.clitic || g.Kind != w.kind {
+					t.Errorf("token %d = %+v, want %+v", i, g, w)
+				}
+				if c.input[g.Start:g.End] != g.Text {
+					t.Errorf("token %d offsets [%d:%d] don't match %q", i, g.Start, g.End, g.Text)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package taphone
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	keyGrammarOnce sync.Once
+	keyGrammar     [3]*regexp.Regexp
+)
+
+// IsValidKey reports whether s is a well formed key at the given level, as
+// produced by an encoder with the default key style. Keys follow this
+// grammar, where the codes are those of the consonant, vowel and loan
+// sound (WithLoanSounds) tables:
+//
+//	key2  = [ mod ] { code [ mod ] }
+//	key1  = [ "3" ] { code [ "3" ] }
+//	key0  = [ "3" ] { base [ "3" ] }
+//	mod   = "3" ... "8" [ "9" ] | "9"
+//	code  = consonant | vowel | loan
+//	base  = code without its "1" (hard sound) suffix
+//
+//	consonant = "K" | "NG" | "C" | "NJ" | "T" | "N" | "T1" | "P" | "M" | "Y"
+//	          | "R" | "L" | "V" | "Z" | "R1" | "N1"
+//	vowel     = "A" | "I" | "U" | "E" | "AI" | "O"
+//	loan      = "D" | "X" | "S" | "H" | "F" | "Q"
+//
+// Keys are never empty. A key starts with a modifier if the word starts
+// with a letter that the encoder drops, such as a Grantha letter without
+// WithLoanSounds (ஜெயா → 5Y at key2). Keys produced with options that
+// change the key style (WithVerboseCodes, WithLowercase,
+// WithDisambiguation, WithLengthBuckets, WithNgramFallback) are not
+// valid.
+func IsValidKey(s string, level KeyLevel) bool {
+	if level < Key0 || level > Key2 {
+		return false
+	}
+	keyGrammarOnce.Do(initKeyGrammar)
+	return s != "" && keyGrammar[level].MatchString(s)
+}
+
+// initKeyGrammar builds the key grammar from the code tables.
+func initKeyGrammar() {
+	var (
+		codes = make(map[string]bool)
+		bases = make(map[string]bool)
+	)
+	for c := range keyCodes() {
+		codes[c] = true
+		bases[strings.TrimSuffix(c, "1")] = true
+	}
+
+	keyGrammar[Key2] = regexp.MustCompile(`^[3-8]?9?(?:` + alternation(codes) + `[3-8]?9?)*$`)
+	keyGrammar[Key1] = regexp.MustCompile(`^3?(?:` + alternation(codes) + `3?)*$`)
+	keyGrammar[Key0] = regexp.MustCompile(`^3?(?:` + alternation(bases) + `3?)*$`)
+}
+
+// alternation returns a regular expression group matching any of the
+// given strings, longest first.
+func alternation(set map[string]bool) string {
+	var s []string
+	for c := range set {
+		s = append(s, regexp.QuoteMeta(c))
+	}
+	sort.Slice(s, func(i, j int) bool {
+		if len(s[i]) != len(s[j]) {
+			return len(s[i]) > len(s[j])
+		}
+		return s[i] < s[j]
+	})
+	return "(?:" + strings.Join(s, "|") + ")"
+}
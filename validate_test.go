@@ -0,0 +1,51 @@
+package taphone
+
+import "testing"
+
+func TestIsValidKey(t *testing.T) {
+	cases := []struct {
+		key   string
+		level KeyLevel
+		want  bool
+	}{
+		{"TM3Z", Key0, true},
+		{"T1M3Z", Key0, false},
+		{"T1M3Z", Key1, true},
+		{"M4R4KN1", Key1, false},
+		{"M4R4KN1", Key2, true},
+		{"K79", Key2, true},
+		{"K97", Key2, false},
+		{"AINGK", Key0, true},
+		{"DXS", Key2, true},
+		{"", Key0, false},
+		{"tm3z", Key0, false},
+		{"T-M3-Z", Key0, false},
+		{"4TM3Z", Key0, false},
+		{"KL:1L", Key1, false},
+		{"3M", Key0, true},
+		{"5Y", Key1, false},
+		{"5Y", Key2, true},
+		{"33K", Key0, false},
+		{"K33", Key1, false},
+		{"TM3Z", KeyLevel(3), false},
+	}
+	for _, c := range cases {
+		if got := IsValidKey(c.key, c.level); got != c.want {
+			t.Errorf("IsValidKey(%q, %d) = %v, want %v", c.key, c.level, got, c.want)
+		}
+	}
+}
+
+func TestIsValidKeyEncoded(t *testing.T) {
+	words := []string{"தமிழ்", "முருகன்", "மௌனம்", "பையன்", "ஃபேன்", "ஜெயா", "ஸ்ரீராம்", "அங்கே", "வெற்றி", "ஜிம்", "ஹோட்டல்", "ஜி"}
+	for _, k := range []*TAphone{New(), New(WithLoanSounds())} {
+		for _, w := range words {
+			keys := k.EncodeKeys(w)
+			for l := Key0; l <= Key2; l++ {
+				if !IsValidKey(keys.Key(l), l) {
+					t.Errorf("IsValidKey(%q, %d) = false for the key of %q", keys.Key(l), l, w)
+				}
+			}
+		}
+	}
+}